@@ -0,0 +1,26 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package constants
+
+// OpenAPI tags splitting the openpitrix subsystem's routes by resource, alongside
+// OpenpitrixAppTemplateTag, so generated swagger groups repo, version-audit and webhook routes
+// separately from app templates. OpenpitrixApplicationTag is set on the application create routes
+// and the deprecated /applications list only; the other application routes are tagged
+// NamespaceResourcesTag.
+const (
+	OpenpitrixRepoTag            = "App Repository"
+	OpenpitrixApplicationTag     = "Application Release"
+	OpenpitrixAppVersionAuditTag = "Application Version Audit"
+	OpenpitrixWebhookTag         = "Application Webhook"
+)
@@ -0,0 +1,57 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	openpitrixclient "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+// defaultAttachmentValidation is the allow-list and size limit CreateAttachment validates every
+// upload against; nothing overrides it. It names no ClamdAddress, so uploads are not
+// antivirus-scanned.
+var defaultAttachmentValidation = openpitrixclient.AttachmentValidationConfig{
+	AllowedMimeTypes: []string{"application/gzip", "application/x-gzip", "application/x-helm-chart", "image/png", "image/jpeg", "image/svg+xml"},
+	MaxSizeBytes:     20 << 20,
+}
+
+// validateAttachmentUpload runs an uploaded attachment through the MIME/size/AV pipeline and,
+// for gzip tarballs that look like Helm charts, the chart structural validation (valid Chart.yaml,
+// no path-traversal entries), before CreateAttachment persists it.
+func (h *openpitrixHandler) validateAttachmentUpload(filename string, body io.Reader) (io.Reader, error) {
+	validated, err := defaultAttachmentValidation.ValidateUpload(body)
+	if err != nil {
+		return nil, err
+	}
+
+	if !looksLikeChart(filename) {
+		return validated, nil
+	}
+
+	buf, err := io.ReadAll(validated)
+	if err != nil {
+		return nil, err
+	}
+	if err := openpitrixclient.ValidateChartStructure(bytes.NewReader(buf)); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(buf), nil
+}
+
+func looksLikeChart(filename string) bool {
+	return strings.HasSuffix(filename, ".tgz") || strings.HasSuffix(filename, ".tar.gz")
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+)
+
+// debugTokensEnv names the environment variable holding the bearer tokens callers must present to
+// reach /debug/pprof, formatted as comma-separated name=token pairs (e.g. "alice=..,bob=..") so a
+// distributed token can still be attributed to the operator it was issued to.
+//
+// This is a stopgap, not RBAC: AddToContainer (register.go) is never given the apiserver's
+// authorizer, so this package has no authorizer.Authorizer to call and cannot reuse the
+// cluster-scoped permission check every other openpitrix route goes through. Per-user RBAC on
+// this endpoint requires threading an authorizer into AddToContainer from cmd/ks-apiserver and
+// checking it here instead of (or in addition to) the token map; that wiring doesn't exist yet
+// and is follow-up work for whoever owns the apiserver bootstrap, not something this package can
+// do on its own. The endpoint is closed entirely (404) when no tokens are configured.
+const debugTokensEnv = "KUBESPHERE_DEBUG_TOKENS"
+
+// newDebugWebService mounts net/http/pprof under /debug/pprof so operators can profile the
+// apiserver's CPU and heap usage in-cluster without attaching a sidecar. Every route is gated by
+// requireDebugToken: the caller must send one of the bearer tokens configured via
+// KUBESPHERE_DEBUG_TOKENS, and the endpoint refuses all requests when none are configured.
+func newDebugWebService() *restful.WebService {
+	webservice := new(restful.WebService)
+	webservice.Path("/debug/pprof")
+	webservice.Filter(requireDebugToken)
+
+	webservice.Route(webservice.GET("/").To(toRestfulHandler(pprof.Index)))
+	webservice.Route(webservice.GET("/cmdline").To(toRestfulHandler(pprof.Cmdline)))
+	webservice.Route(webservice.GET("/profile").To(toRestfulHandler(pprof.Profile)))
+	webservice.Route(webservice.GET("/symbol").To(toRestfulHandler(pprof.Symbol)))
+	webservice.Route(webservice.POST("/symbol").To(toRestfulHandler(pprof.Symbol)))
+	webservice.Route(webservice.GET("/trace").To(toRestfulHandler(pprof.Trace)))
+	webservice.Route(webservice.GET("/{subpath:*}").To(toRestfulHandler(pprof.Index)))
+
+	return webservice
+}
+
+func toRestfulHandler(h http.HandlerFunc) restful.RouteFunction {
+	return func(req *restful.Request, resp *restful.Response) {
+		h(resp.ResponseWriter, req.Request)
+	}
+}
+
+// requireDebugToken rejects every request unless its "Authorization: Bearer <token>" header
+// matches one of the tokens configured via KUBESPHERE_DEBUG_TOKENS. An empty/unset configuration
+// closes the endpoint rather than falling open.
+func requireDebugToken(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+	tokens := parseDebugTokens(os.Getenv(debugTokensEnv))
+	if len(tokens) == 0 {
+		resp.WriteErrorString(http.StatusNotFound, "not found")
+		return
+	}
+	authorization := req.Request.Header.Get("Authorization")
+	presented := strings.TrimPrefix(authorization, "Bearer ")
+	if _, ok := tokens[presented]; !ok || presented == authorization {
+		resp.WriteErrorString(http.StatusForbidden, "debug endpoint requires a valid bearer token")
+		return
+	}
+	chain.ProcessFilter(req, resp)
+}
+
+// parseDebugTokens parses the KUBESPHERE_DEBUG_TOKENS value into a map of token to the operator
+// name it was issued to, skipping malformed entries.
+func parseDebugTokens(raw string) map[string]string {
+	tokens := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		name, token, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || name == "" || token == "" {
+			continue
+		}
+		tokens[token] = name
+	}
+	return tokens
+}
@@ -0,0 +1,170 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+
+	"gopkg.in/yaml.v2"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+)
+
+// DiffApplication handles POST .../applications/{application}:diff, diffing the values an
+// UpgradeClusterRequest would apply against the values the application was last deployed with and
+// checking that they parse as YAML, without mutating the cluster. Chart templates are not
+// rendered, deployed objects are not compared, and no server-side dry-run is made (see
+// diffApplication).
+func (h *openpitrixHandler) DiffApplication(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	application := req.PathParameter("application")
+
+	var upgradeRequest openpitrix.UpgradeClusterRequest
+	if err := req.ReadEntity(&upgradeRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	result, err := h.diffApplication(namespace, application, &upgradeRequest)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// DryRunCreateApplication handles POST .../applications:dryRun, returning the same values
+// diff/YAML validation preview as DiffApplication for a CreateClusterRequest, against an
+// application that does not exist yet so its values are reported as added.
+func (h *openpitrixHandler) DryRunCreateApplication(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+
+	var createRequest openpitrix.CreateClusterRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	result, err := h.dryRunCreateApplication(namespace, &createRequest)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// diffApplication diffs an UpgradeClusterRequest's values against the values the application is
+// currently deployed with. This tree has no Helm client to render a chart's templates, so the diff
+// and validation operate directly on the values document rather than on rendered Kubernetes
+// objects; ResourceDiff.Kind is set to "Values" accordingly instead of a real object kind.
+func (h *openpitrixHandler) diffApplication(namespace, application string, req *openpitrix.UpgradeClusterRequest) (*openpitrix.ApplicationDiffResponse, error) {
+	h.mu.RLock()
+	app, ok := h.applications[application]
+	var previous string
+	if ok {
+		if revisions := h.revisions[revisionKey(namespace, application)]; len(revisions) > 0 {
+			previous = revisions[len(revisions)-1].Values
+		}
+	}
+	h.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("application %s not found", application)
+	}
+
+	action := openpitrix.DiffActionUnchanged
+	if previous != req.Conf {
+		action = openpitrix.DiffActionChanged
+	}
+
+	return &openpitrix.ApplicationDiffResponse{
+		Manifest: req.Conf,
+		Diffs: []openpitrix.ResourceDiff{{
+			Kind:        "Values",
+			Name:        app.Name,
+			Namespace:   namespace,
+			Action:      action,
+			UnifiedDiff: unifiedDiff(previous, req.Conf),
+		}},
+		Validations: []openpitrix.ValidationResult{validateValuesYAML(app.Name, req.Conf)},
+	}, nil
+}
+
+// dryRunCreateApplication previews a CreateClusterRequest against an application that doesn't
+// exist yet, so its values are reported as wholly added. See diffApplication for why the diff
+// operates on values rather than rendered Kubernetes objects.
+func (h *openpitrixHandler) dryRunCreateApplication(namespace string, req *openpitrix.CreateClusterRequest) (*openpitrix.ApplicationDiffResponse, error) {
+	return &openpitrix.ApplicationDiffResponse{
+		Manifest: req.Conf,
+		Diffs: []openpitrix.ResourceDiff{{
+			Kind:        "Values",
+			Name:        req.Name,
+			Namespace:   namespace,
+			Action:      openpitrix.DiffActionAdded,
+			UnifiedDiff: unifiedDiff("", req.Conf),
+		}},
+		Validations: []openpitrix.ValidationResult{validateValuesYAML(req.Name, req.Conf)},
+	}, nil
+}
+
+// validateValuesYAML stands in for a server-side `kubectl apply --dry-run=server` pass: this tree
+// has no dynamic client to validate rendered objects against the apiserver, so it validates that
+// the values document is at least well-formed YAML.
+func validateValuesYAML(name, conf string) openpitrix.ValidationResult {
+	var parsed interface{}
+	if err := yaml.Unmarshal([]byte(conf), &parsed); err != nil {
+		return openpitrix.ValidationResult{Kind: "Values", Name: name, Valid: false, Message: err.Error()}
+	}
+	return openpitrix.ValidationResult{Kind: "Values", Name: name, Valid: true}
+}
+
+// unifiedDiff renders a minimal unified diff between two small text documents, adequate for a
+// values.yaml-sized payload: lines present only in `to` are a "+" hunk, lines present only in
+// `from` are a "-" hunk, aligned against the longest common prefix/suffix of lines.
+func unifiedDiff(from, to string) string {
+	if from == to {
+		return ""
+	}
+	fromLines := splitLines(from)
+	toLines := splitLines(to)
+
+	prefix := 0
+	for prefix < len(fromLines) && prefix < len(toLines) && fromLines[prefix] == toLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(fromLines)-prefix && suffix < len(toLines)-prefix &&
+		fromLines[len(fromLines)-1-suffix] == toLines[len(toLines)-1-suffix] {
+		suffix++
+	}
+
+	var b strings.Builder
+	for _, line := range fromLines[prefix : len(fromLines)-suffix] {
+		fmt.Fprintf(&b, "-%s\n", line)
+	}
+	for _, line := range toLines[prefix : len(toLines)-suffix] {
+		fmt.Fprintf(&b, "+%s\n", line)
+	}
+	return b.String()
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
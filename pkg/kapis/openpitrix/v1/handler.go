@@ -0,0 +1,780 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	restful "github.com/emicklei/go-restful"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/client/clientset/versioned"
+	"kubesphere.io/kubesphere/pkg/informers"
+	"kubesphere.io/kubesphere/pkg/models"
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	"kubesphere.io/kubesphere/pkg/server/errors"
+	openpitrixoptions "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+// openpitrixHandler serves the openpitrix v1 API. Repos, app templates/versions, categories,
+// attachments and applications are persisted as CRDs in the real deployment; this package keeps
+// its working set in memory, guarded by mu, so the handler methods below have a single place to
+// read and write state from.
+type openpitrixHandler struct {
+	informers informers.InformerFactory
+	ksClient  versioned.Interface
+	options   *openpitrixoptions.Options
+	stopCh    <-chan struct{}
+
+	mu           sync.RWMutex
+	seq          uint64
+	repos        map[string]*openpitrix.Repo
+	appVersions  map[string]*openpitrix.AppVersion
+	audits       map[string][]*openpitrix.AppVersionAudit
+	categories   map[string]*openpitrix.Category
+	attachments  map[string]*openpitrix.Attachment
+	applications map[string]*openpitrix.Application
+	revisions    map[string][]*openpitrix.ApplicationRevisionDetail
+	webhooks     map[string]*openpitrix.Webhook
+	deliveries   map[string][]*openpitrix.WebhookDelivery
+	dispatcher   *openpitrixoptions.WebhookDispatcher
+}
+
+// handlerDeliveryStore adapts openpitrixHandler's in-memory deliveries map to the
+// openpitrixoptions.DeliveryStore interface WebhookDispatcher.Dispatch writes through.
+type handlerDeliveryStore struct {
+	h *openpitrixHandler
+}
+
+func (s *handlerDeliveryStore) Save(delivery *openpitrix.WebhookDelivery) error {
+	s.h.mu.Lock()
+	defer s.h.mu.Unlock()
+	delivery.ID = s.h.nextID("delivery")
+	s.h.deliveries[delivery.WebhookID] = append(s.h.deliveries[delivery.WebhookID], delivery)
+	return nil
+}
+
+func newOpenpitrixHandler(informers informers.InformerFactory, ksClient versioned.Interface, options *openpitrixoptions.Options, stopCh <-chan struct{}) *openpitrixHandler {
+	h := &openpitrixHandler{
+		informers:    informers,
+		ksClient:     ksClient,
+		options:      options,
+		stopCh:       stopCh,
+		repos:        make(map[string]*openpitrix.Repo),
+		appVersions:  make(map[string]*openpitrix.AppVersion),
+		audits:       make(map[string][]*openpitrix.AppVersionAudit),
+		categories:   make(map[string]*openpitrix.Category),
+		attachments:  make(map[string]*openpitrix.Attachment),
+		applications: make(map[string]*openpitrix.Application),
+		webhooks:     make(map[string]*openpitrix.Webhook),
+		deliveries:   make(map[string][]*openpitrix.WebhookDelivery),
+		revisions:    make(map[string][]*openpitrix.ApplicationRevisionDetail),
+	}
+	h.dispatcher = openpitrixoptions.NewWebhookDispatcher(&handlerDeliveryStore{h: h}, 3, time.Second)
+	return h
+}
+
+// nextID generates a process-unique id for the given resource kind, e.g. "repo-1".
+func (h *openpitrixHandler) nextID(kind string) string {
+	return fmt.Sprintf("%s-%d", kind, atomic.AddUint64(&h.seq, 1))
+}
+
+func now() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+// ---- repos ----
+
+func (h *openpitrixHandler) CreateRepo(req *restful.Request, resp *restful.Response) {
+	var createRequest openpitrix.CreateRepoRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	if createRequest.Type == "" {
+		createRequest.Type = openpitrix.RepoTypeHTTP
+	}
+
+	h.mu.Lock()
+	repo := &openpitrix.Repo{
+		RepoId:       h.nextID("repo"),
+		Name:         createRequest.Name,
+		URL:          createRequest.URL,
+		Workspace:    req.PathParameter("workspace"),
+		Description:  createRequest.Description,
+		Type:         createRequest.Type,
+		Credential:   createRequest.Credential,
+		Verification: createRequest.Verification,
+		Status:       "active",
+		CreateTime:   now(),
+	}
+	h.repos[repo.RepoId] = repo
+	h.mu.Unlock()
+
+	resp.WriteEntity(openpitrix.CreateRepoResponse{RepoId: repo.RepoId})
+}
+
+func (h *openpitrixHandler) DeleteRepo(req *restful.Request, resp *restful.Response) {
+	h.mu.Lock()
+	delete(h.repos, req.PathParameter("repo"))
+	h.mu.Unlock()
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) ListRepos(req *restful.Request, resp *restful.Response) {
+	workspace := req.PathParameter("workspace")
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	items := make([]interface{}, 0, len(h.repos))
+	for _, repo := range h.repos {
+		if workspace != "" && repo.Workspace != workspace {
+			continue
+		}
+		items = append(items, repo)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+// describeRepo looks up a repo by id, used both by the exported DescribeRepo handler and by
+// ListOCIChartTags (oci_install.go) to resolve the registry a chart tag listing targets.
+func (h *openpitrixHandler) describeRepo(repoID string) (*openpitrix.Repo, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	repo, ok := h.repos[repoID]
+	if !ok {
+		return nil, fmt.Errorf("repo %s not found", repoID)
+	}
+	return repo, nil
+}
+
+func (h *openpitrixHandler) DescribeRepo(req *restful.Request, resp *restful.Response) {
+	repo, err := h.describeRepo(req.PathParameter("repo"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(repo)
+}
+
+func (h *openpitrixHandler) ModifyRepo(req *restful.Request, resp *restful.Response) {
+	var modifyRequest openpitrix.ModifyRepoRequest
+	if err := req.ReadEntity(&modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	repo, ok := h.repos[req.PathParameter("repo")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("repo %s not found", req.PathParameter("repo")))
+		return
+	}
+	if modifyRequest.Name != "" {
+		repo.Name = modifyRequest.Name
+	}
+	if modifyRequest.URL != "" {
+		repo.URL = modifyRequest.URL
+	}
+	if modifyRequest.Description != "" {
+		repo.Description = modifyRequest.Description
+	}
+	if modifyRequest.Credential != nil {
+		repo.Credential = modifyRequest.Credential
+	}
+	if modifyRequest.Verification != nil {
+		repo.Verification = modifyRequest.Verification
+	}
+	repo.StatusTime = now()
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) ListRepoEvents(req *restful.Request, resp *restful.Response) {
+	resp.WriteEntity(models.PageableResponse{Items: []interface{}{}, TotalCount: 0})
+}
+
+// DoRepoAction indexes a repo: for an OCI-backed repo this walks the registry's catalog and tag
+// list (indexOCIRepo, oci_index.go); classic HTTP repos are indexed from their index.yaml
+// (indexHTTPRepo, http_index.go).
+func (h *openpitrixHandler) DoRepoAction(req *restful.Request, resp *restful.Response) {
+	var actionRequest openpitrix.RepoActionRequest
+	if err := req.ReadEntity(&actionRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	repo, err := h.describeRepo(req.PathParameter("repo"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	var versions []*openpitrix.AppVersion
+	if repo.Type == openpitrix.RepoTypeOCI {
+		versions, err = h.indexOCIRepo(repo)
+	} else {
+		versions, err = h.indexHTTPRepo(repo)
+	}
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	for _, version := range versions {
+		version.VersionId = h.nextID("version")
+		version.RepoId = repo.RepoId
+		version.Status = "active"
+		version.CreateTime = now()
+		h.appVersions[version.VersionId] = version
+	}
+	h.mu.Unlock()
+
+	resp.WriteEntity(errors.None)
+}
+
+// ---- app templates ----
+
+func (h *openpitrixHandler) CreateApp(req *restful.Request, resp *restful.Response) {
+	var createRequest openpitrix.CreateAppRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(openpitrix.CreateAppResponse{AppId: h.nextID("app")})
+}
+
+func (h *openpitrixHandler) ModifyApp(req *restful.Request, resp *restful.Response) {
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) ListApps(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	seen := map[string]bool{}
+	items := make([]interface{}, 0)
+	for _, version := range h.appVersions {
+		if seen[version.AppId] {
+			continue
+		}
+		seen[version.AppId] = true
+		items = append(items, version)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+func (h *openpitrixHandler) DescribeApp(req *restful.Request, resp *restful.Response) {
+	appID := req.PathParameter("app")
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for _, version := range h.appVersions {
+		if version.AppId == appID {
+			resp.WriteEntity(version)
+			return
+		}
+	}
+	api.HandleError(resp, req, fmt.Errorf("app %s not found", appID))
+}
+
+func (h *openpitrixHandler) DeleteApp(req *restful.Request, resp *restful.Response) {
+	appID := req.PathParameter("app")
+
+	h.mu.Lock()
+	for id, version := range h.appVersions {
+		if version.AppId == appID {
+			delete(h.appVersions, id)
+		}
+	}
+	h.mu.Unlock()
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) DoAppAction(req *restful.Request, resp *restful.Response) {
+	resp.WriteEntity(errors.None)
+}
+
+// ---- app versions ----
+
+func (h *openpitrixHandler) CreateAppVersion(req *restful.Request, resp *restful.Response) {
+	var createRequest openpitrix.CreateAppVersionRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	version := &openpitrix.AppVersion{
+		VersionId:   h.nextID("version"),
+		AppId:       req.PathParameter("app"),
+		Name:        createRequest.Name,
+		Description: createRequest.Description,
+		Status:      "active",
+		CreateTime:  now(),
+	}
+	h.appVersions[version.VersionId] = version
+	h.mu.Unlock()
+
+	resp.WriteEntity(openpitrix.CreateAppVersionResponse{VersionId: version.VersionId})
+}
+
+func (h *openpitrixHandler) DeleteAppVersion(req *restful.Request, resp *restful.Response) {
+	h.mu.Lock()
+	delete(h.appVersions, req.PathParameter("version"))
+	h.mu.Unlock()
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) DescribeAppVersion(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	version, ok := h.appVersions[req.PathParameter("version")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("app version %s not found", req.PathParameter("version")))
+		return
+	}
+	resp.WriteEntity(version)
+}
+
+func (h *openpitrixHandler) ListAppVersions(req *restful.Request, resp *restful.Response) {
+	appID := req.PathParameter("app")
+	verifiedOnly := req.QueryParameter("verified") == "true"
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	items := make([]interface{}, 0)
+	for _, version := range h.appVersions {
+		if appID != "" && version.AppId != appID {
+			continue
+		}
+		if verifiedOnly && !version.Verified {
+			continue
+		}
+		items = append(items, version)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+func (h *openpitrixHandler) GetAppVersionPackage(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	version, ok := h.appVersions[req.PathParameter("version")]
+	h.mu.RUnlock()
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("app version %s not found", req.PathParameter("version")))
+		return
+	}
+
+	if version.RepoId == "" {
+		resp.WriteEntity(openpitrix.GetAppVersionPackageResponse{VersionId: version.VersionId})
+		return
+	}
+
+	repo, err := h.describeRepo(version.RepoId)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	if repo.Type != openpitrix.RepoTypeOCI {
+		resp.WriteEntity(openpitrix.GetAppVersionPackageResponse{VersionId: version.VersionId})
+		return
+	}
+
+	chartName, tag, ok := splitPackageName(version.PackageName)
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("app version %s has no oci package reference", version.VersionId))
+		return
+	}
+	blob, err := h.streamOCIPackage(repo, chartName, tag)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	defer blob.Close()
+
+	pkg, err := readAll(blob)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(openpitrix.GetAppVersionPackageResponse{VersionId: version.VersionId, Package: pkg})
+}
+
+func (h *openpitrixHandler) ModifyAppVersion(req *restful.Request, resp *restful.Response) {
+	var modifyRequest openpitrix.ModifyAppVersionRequest
+	if err := req.ReadEntity(&modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	version, ok := h.appVersions[req.PathParameter("version")]
+	if !ok {
+		version, ok = h.appVersions[req.PathParameter("app")]
+	}
+	if !ok {
+		resp.WriteEntity(errors.None)
+		return
+	}
+	if modifyRequest.Name != "" {
+		version.Name = modifyRequest.Name
+	}
+	if modifyRequest.Description != "" {
+		version.Description = modifyRequest.Description
+	}
+	if modifyRequest.Status != "" {
+		version.Status = modifyRequest.Status
+	}
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) GetAppVersionFiles(req *restful.Request, resp *restful.Response) {
+	resp.WriteEntity(openpitrix.GetAppVersionPackageFilesResponse{VersionId: req.PathParameter("version"), Files: map[string][]byte{}})
+}
+
+func (h *openpitrixHandler) ListAppVersionAudits(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	audits := h.audits[req.PathParameter("app")]
+	items := make([]interface{}, 0, len(audits))
+	for _, audit := range audits {
+		items = append(items, audit)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+// DoAppVersionAction handles version-level lifecycle actions (currently only "submit"). Submitting
+// a version backed by a repo with a Verification policy configured runs verifyChartVersion against
+// its package before recording the audit entry, so ListAppVersions's ?verified=true filter and the
+// version's Verified/Signer fields reflect a real check rather than an unset default.
+func (h *openpitrixHandler) DoAppVersionAction(req *restful.Request, resp *restful.Response) {
+	appID, versionID := req.PathParameter("app"), req.PathParameter("version")
+
+	status, message := "submitted", ""
+	h.mu.Lock()
+	if version, ok := h.appVersions[versionID]; ok {
+		var verification *openpitrix.Verification
+		if repo, ok := h.repos[version.RepoId]; ok {
+			verification = repo.Verification
+		}
+		if verification != nil && version.PackageURL != "" {
+			verified, signer, err := h.verifyChartVersion(verification, version.PackageURL)
+			if err != nil {
+				status, message = "verification_failed", err.Error()
+				if verification.Strict {
+					version.Status = "rejected"
+				}
+			} else {
+				version.Verified, version.Signer = verified, signer
+			}
+		}
+	}
+	h.audits[appID] = append(h.audits[appID], &openpitrix.AppVersionAudit{
+		VersionId: versionID,
+		AppId:     appID,
+		Status:    status,
+		Message:   message,
+		Time:      now(),
+	})
+	h.mu.Unlock()
+
+	resp.WriteEntity(errors.None)
+}
+
+// ---- categories ----
+
+func (h *openpitrixHandler) CreateCategory(req *restful.Request, resp *restful.Response) {
+	var createRequest openpitrix.CreateCategoryRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	category := &openpitrix.Category{
+		CategoryId:  h.nextID("category"),
+		Name:        createRequest.Name,
+		Description: createRequest.Description,
+	}
+	h.categories[category.CategoryId] = category
+	h.mu.Unlock()
+
+	resp.WriteEntity(openpitrix.CreateCategoryResponse{CategoryId: category.CategoryId})
+}
+
+func (h *openpitrixHandler) DeleteCategory(req *restful.Request, resp *restful.Response) {
+	h.mu.Lock()
+	delete(h.categories, req.PathParameter("category"))
+	h.mu.Unlock()
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) ModifyCategory(req *restful.Request, resp *restful.Response) {
+	var modifyRequest openpitrix.ModifyCategoryRequest
+	if err := req.ReadEntity(&modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	category, ok := h.categories[req.PathParameter("category")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("category %s not found", req.PathParameter("category")))
+		return
+	}
+	if modifyRequest.Name != "" {
+		category.Name = modifyRequest.Name
+	}
+	if modifyRequest.Description != "" {
+		category.Description = modifyRequest.Description
+	}
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) DescribeCategory(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	category, ok := h.categories[req.PathParameter("category")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("category %s not found", req.PathParameter("category")))
+		return
+	}
+	resp.WriteEntity(category)
+}
+
+func (h *openpitrixHandler) ListCategories(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	items := make([]interface{}, 0, len(h.categories))
+	for _, category := range h.categories {
+		items = append(items, category)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+// ---- reviews ----
+
+func (h *openpitrixHandler) ListReviews(req *restful.Request, resp *restful.Response) {
+	resp.WriteEntity(models.PageableResponse{Items: []interface{}{}, TotalCount: 0})
+}
+
+// ---- attachments ----
+
+func (h *openpitrixHandler) DescribeAttachment(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	attachment, ok := h.attachments[req.PathParameter("attachment")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("attachment %s not found", req.PathParameter("attachment")))
+		return
+	}
+	resp.WriteEntity(attachment)
+}
+
+func (h *openpitrixHandler) CreateAttachment(req *restful.Request, resp *restful.Response) {
+	file, header, err := req.Request.FormFile("file")
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	defer file.Close()
+
+	validated, err := h.validateAttachmentUpload(header.Filename, file)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	content, err := readAll(validated)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	attachment := &openpitrix.Attachment{
+		AttachmentId: h.nextID("attachment"),
+		Filename:     header.Filename,
+		Content:      content,
+	}
+	h.attachments[attachment.AttachmentId] = attachment
+	h.mu.Unlock()
+
+	resp.WriteEntity(attachment)
+}
+
+// DeleteAttachments deletes one or multiple attachments, whose ids are passed in the path
+// parameter separated by comma.
+func (h *openpitrixHandler) DeleteAttachments(req *restful.Request, resp *restful.Response) {
+	h.mu.Lock()
+	for _, id := range strings.Split(req.PathParameter("attachment"), ",") {
+		delete(h.attachments, id)
+	}
+	h.mu.Unlock()
+	resp.WriteEntity(errors.None)
+}
+
+// ---- applications ----
+
+func (h *openpitrixHandler) ListApplications(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	items := make([]interface{}, 0)
+	for _, application := range h.applications {
+		if namespace != "" && application.Namespace != namespace {
+			continue
+		}
+		items = append(items, application)
+	}
+	resp.WriteEntity(models.PageableResponse{Items: items, TotalCount: len(items)})
+}
+
+func (h *openpitrixHandler) ModifyApplication(req *restful.Request, resp *restful.Response) {
+	var modifyRequest openpitrix.ModifyClusterAttributesRequest
+	if err := req.ReadEntity(&modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	application, ok := h.applications[req.PathParameter("application")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("application %s not found", req.PathParameter("application")))
+		return
+	}
+	if modifyRequest.Description != "" {
+		application.Description = modifyRequest.Description
+	}
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) CreateApplication(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+
+	var createRequest openpitrix.CreateClusterRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	application := &openpitrix.Application{
+		ApplicationId: h.nextID("application"),
+		Name:          createRequest.Name,
+		Workspace:     req.PathParameter("workspace"),
+		Cluster:       req.PathParameter("cluster"),
+		Namespace:     namespace,
+		AppId:         createRequest.AppId,
+		VersionId:     createRequest.VersionId,
+		Status:        "deploying",
+		CreateTime:    now(),
+	}
+	h.applications[application.ApplicationId] = application
+	h.mu.Unlock()
+
+	chartRef := createRequest.VersionId
+	if !openpitrix.IsOCIChartRef(chartRef) {
+		chartRef = createRequest.AppId
+	}
+	if openpitrix.IsOCIChartRef(chartRef) {
+		h.resolveOCIChartRef(application, namespace, chartRef, createRequest.ImagePullSecretRef)
+	}
+	h.recordRevision(namespace, application.ApplicationId, createRequest.VersionId, createRequest.Conf, "installed")
+	h.dispatchWebhookEvent(openpitrix.WebhookEventApplicationCreated, application)
+
+	resp.WriteEntity(openpitrix.CreateApplicationResponse{ApplicationId: application.ApplicationId})
+}
+
+func (h *openpitrixHandler) UpgradeApplication(req *restful.Request, resp *restful.Response) {
+	var upgradeRequest openpitrix.UpgradeClusterRequest
+	if err := req.ReadEntity(&upgradeRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	h.mu.Lock()
+	application, ok := h.applications[req.PathParameter("application")]
+	if !ok {
+		h.mu.Unlock()
+		api.HandleError(resp, req, fmt.Errorf("application %s not found", req.PathParameter("application")))
+		return
+	}
+	application.VersionId = upgradeRequest.VersionId
+	application.Status = "upgrading"
+	h.mu.Unlock()
+
+	if openpitrix.IsOCIChartRef(upgradeRequest.VersionId) {
+		h.resolveOCIChartRef(application, application.Namespace, upgradeRequest.VersionId, upgradeRequest.ImagePullSecretRef)
+	}
+	h.recordRevision(application.Namespace, application.ApplicationId, upgradeRequest.VersionId, upgradeRequest.Conf, "upgraded")
+	h.dispatchWebhookEvent(openpitrix.WebhookEventApplicationUpgraded, application)
+
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) DescribeApplication(req *restful.Request, resp *restful.Response) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	application, ok := h.applications[req.PathParameter("application")]
+	if !ok {
+		api.HandleError(resp, req, fmt.Errorf("application %s not found", req.PathParameter("application")))
+		return
+	}
+	resp.WriteEntity(application)
+}
+
+func (h *openpitrixHandler) DeleteApplication(req *restful.Request, resp *restful.Response) {
+	h.mu.Lock()
+	application, ok := h.applications[req.PathParameter("application")]
+	delete(h.applications, req.PathParameter("application"))
+	h.mu.Unlock()
+
+	if ok {
+		h.dispatchWebhookEvent(openpitrix.WebhookEventApplicationDeleted, application)
+	}
+	resp.WriteEntity(errors.None)
+}
+
+// dispatchWebhookEvent fires event to every registered Webhook whose filters match, via
+// WebhookDispatcher.Dispatch, in a goroutine per subscriber so a slow or unreachable subscriber
+// can't hold up the application lifecycle request that triggered it.
+func (h *openpitrixHandler) dispatchWebhookEvent(event openpitrix.WebhookEvent, application *openpitrix.Application) {
+	payload := &openpitrixoptions.WebhookEventPayload{
+		Event:       event,
+		Workspace:   application.Workspace,
+		Namespace:   application.Namespace,
+		Application: application.ApplicationId,
+		OccurredAt:  now(),
+	}
+
+	h.mu.RLock()
+	hooks := make([]openpitrix.Webhook, 0, len(h.webhooks))
+	for _, hook := range h.webhooks {
+		hooks = append(hooks, *hook)
+	}
+	h.mu.RUnlock()
+
+	for i := range hooks {
+		hook := hooks[i]
+		go h.dispatcher.Dispatch(&hook, payload)
+	}
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+)
+
+func readAll(r io.Reader) ([]byte, error) {
+	return io.ReadAll(r)
+}
+
+// splitPackageName splits the "chart:tag" form AppVersion.PackageName is stored as by
+// appVersionFromChartMetadata (oci_index.go) back into its chart name and tag.
+func splitPackageName(packageName string) (chart, tag string, ok bool) {
+	chart, tag, found := strings.Cut(packageName, ":")
+	return chart, tag, found
+}
+
+// resolveOCIChartRef pulls the chart tarball an oci:// reference (CreateClusterRequest.AppId or
+// VersionId, UpgradeClusterRequest.VersionId) points at and records its digest on application, or marks
+// application failed if the pull or the credential lookup didn't succeed.
+func (h *openpitrixHandler) resolveOCIChartRef(application *openpitrix.Application, namespace, ref string, secretRef *openpitrix.ImagePullSecretRef) {
+	chartRef, err := openpitrix.ParseChartReference(ref)
+	if err != nil {
+		h.failApplication(application, err)
+		return
+	}
+
+	credential, err := h.credentialForSecretRef(namespace, chartRef.Registry, secretRef)
+	if err != nil {
+		h.failApplication(application, err)
+		return
+	}
+
+	_, digest, err := h.pullOCIChart(chartRef, credential)
+	if err != nil {
+		h.failApplication(application, err)
+		return
+	}
+
+	h.mu.Lock()
+	application.ChartRef = chartRef.String()
+	application.ChartDigest = digest
+	application.Status = "active"
+	h.mu.Unlock()
+}
+
+// credentialForSecretRef resolves an ImagePullSecretRef to a registry credential by reading the
+// named kubernetes.io/dockerconfigjson secret out of namespace and decoding it with
+// credentialForRegistry (oci_install.go). A nil secretRef means the registry is pulled
+// anonymously.
+func (h *openpitrixHandler) credentialForSecretRef(namespace, registry string, secretRef *openpitrix.ImagePullSecretRef) (*openpitrix.OCIRegistryCredential, error) {
+	if secretRef == nil {
+		return nil, nil
+	}
+	if h.informers == nil {
+		return nil, fmt.Errorf("no secret lister available to resolve image pull secret %s", secretRef.Name)
+	}
+
+	secret, err := h.informers.KubernetesSharedInformerFactory().Core().V1().Secrets().
+		Lister().Secrets(namespace).Get(secretRef.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image pull secret %s/%s: %s", namespace, secretRef.Name, err)
+	}
+
+	return credentialForRegistry(secret.Data[".dockerconfigjson"], registry)
+}
+
+func (h *openpitrixHandler) failApplication(application *openpitrix.Application, err error) {
+	h.mu.Lock()
+	application.Status = "failed"
+	application.Description = fmt.Sprintf("failed to resolve oci chart reference: %s", err)
+	h.mu.Unlock()
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+)
+
+// helmRepoIndex mirrors the subset of a classic Helm index.yaml needed to populate app-version
+// metadata: a map of chart name to its published chart versions.
+type helmRepoIndex struct {
+	Entries map[string][]helmRepoIndexEntry `yaml:"entries"`
+}
+
+type helmRepoIndexEntry struct {
+	Name        string   `yaml:"name"`
+	Version     string   `yaml:"version"`
+	Description string   `yaml:"description"`
+	URLs        []string `yaml:"urls"`
+}
+
+// indexHTTPRepo downloads and parses a classic Helm repository's index.yaml, verifying each
+// entry's chart tarball against repo's configured Verification policy before accepting it -
+// mirroring what indexOCIRepo (oci_index.go) does for OCI-backed repos.
+func (h *openpitrixHandler) indexHTTPRepo(repo *openpitrix.Repo) ([]*openpitrix.AppVersion, error) {
+	indexURL := strings.TrimSuffix(repo.URL, "/") + "/index.yaml"
+	resp, err := http.Get(indexURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch %s: %s", indexURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, indexURL)
+	}
+
+	var index helmRepoIndex
+	if err := yaml.NewDecoder(resp.Body).Decode(&index); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %s", indexURL, err)
+	}
+
+	var versions []*openpitrix.AppVersion
+	for chartName, entries := range index.Entries {
+		for _, entry := range entries {
+			if len(entry.URLs) == 0 {
+				continue
+			}
+			version := &openpitrix.AppVersion{
+				Name:        chartName,
+				Owner:       repo.Name,
+				PackageName: fmt.Sprintf("%s:%s", chartName, entry.Version),
+				PackageURL:  entry.URLs[0],
+				Description: entry.Description,
+			}
+
+			verified, signer, err := h.verifyChartVersion(repo.Verification, version.PackageURL)
+			if err != nil {
+				if repo.Verification != nil && repo.Verification.Strict {
+					return nil, fmt.Errorf("chart %s failed verification: %s", version.PackageName, err)
+				}
+			} else {
+				version.Verified = verified
+				version.Signer = signer
+			}
+
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
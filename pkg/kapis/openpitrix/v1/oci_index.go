@@ -0,0 +1,114 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	openpitrixclient "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+func newOCIRegistryClient(registryURL string, credential *openpitrix.OCIRegistryCredential) (*openpitrixclient.OCIRegistryClient, error) {
+	return openpitrixclient.NewOCIRegistryClient(registryURL, credential)
+}
+
+// chartMetadata mirrors the subset of Chart.yaml fields needed to populate an AppVersion record.
+type chartMetadata struct {
+	Name        string `yaml:"name"`
+	Version     string `yaml:"version"`
+	Description string `yaml:"description"`
+	AppVersion  string `yaml:"appVersion"`
+}
+
+func appVersionFromChartMetadata(repo *openpitrix.Repo, chartName, tag string, raw []byte) *openpitrix.AppVersion {
+	meta := chartMetadata{Name: chartName, Version: tag}
+	_ = yaml.Unmarshal(raw, &meta)
+	return &openpitrix.AppVersion{
+		Name:        meta.Name,
+		Owner:       repo.Name,
+		PackageName: fmt.Sprintf("%s:%s", chartName, tag),
+		Description: meta.Description,
+	}
+}
+
+// indexOCIRepo discovers chart versions hosted in an OCI-conforming registry by walking the
+// registry's `_catalog` and per-repository tag list, pulling the
+// application/vnd.cncf.helm.chart.content.v1.tar+gzip blob for each tag and extracting the
+// embedded Chart.yaml to populate app-version metadata, mirroring what indexing an index.yaml
+// does for classic HTTP repositories - including running each chart through repo's configured
+// Verification policy via verifyOCIChartVersion before accepting it.
+func (h *openpitrixHandler) indexOCIRepo(repo *openpitrix.Repo) ([]*openpitrix.AppVersion, error) {
+	client, err := newOCIRegistryClient(repo.URL, repo.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to oci registry %s: %s", repo.URL, err)
+	}
+
+	repositories, err := client.Catalog()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oci catalog: %s", err)
+	}
+
+	var versions []*openpitrix.AppVersion
+	for _, name := range repositories {
+		tags, err := client.Tags(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list tags for %s: %s", name, err)
+		}
+		for _, tag := range tags {
+			if strings.HasSuffix(tag, ".sig") {
+				// cosign publishes a chart's detached signature as its own tag
+				// (cosignSignatureTag) in the same repository; it has no chart-content
+				// layer, so pulling it as a chart would always fail.
+				continue
+			}
+			tgz, digest, err := client.PullChartTarball(name, tag)
+			if err != nil {
+				return nil, fmt.Errorf("failed to pull chart %s:%s: %s", name, tag, err)
+			}
+			chartYaml, err := openpitrixclient.ChartYaml(tgz)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read chart metadata for %s:%s: %s", name, tag, err)
+			}
+			version := appVersionFromChartMetadata(repo, name, tag, chartYaml)
+
+			verified, signer, err := h.verifyOCIChartVersion(repo.Verification, client, name, tgz, digest)
+			if err != nil {
+				if repo.Verification != nil && repo.Verification.Strict {
+					return nil, fmt.Errorf("chart %s failed verification: %s", version.PackageName, err)
+				}
+			} else {
+				version.Verified = verified
+				version.Signer = signer
+			}
+
+			versions = append(versions, version)
+		}
+	}
+	return versions, nil
+}
+
+// streamOCIPackage streams the chart tarball blob for the given app version directly out of the
+// backing OCI registry, in place of reading it from an object store.
+func (h *openpitrixHandler) streamOCIPackage(repo *openpitrix.Repo, chartName, tag string) (io.ReadCloser, error) {
+	client, err := newOCIRegistryClient(repo.URL, repo.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to oci registry %s: %s", repo.URL, err)
+	}
+	return client.PullChartBlob(chartName, tag)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	openpitrixclient "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+// dockerConfigJSON mirrors the .dockerconfigjson payload of a kubernetes.io/dockerconfigjson
+// secret, as referenced by CreateClusterRequest/UpgradeClusterRequest.ImagePullSecretRef.
+type dockerConfigJSON struct {
+	Auths map[string]struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+		Auth     string `json:"auth"`
+	} `json:"auths"`
+}
+
+// credentialForRegistry extracts the credential for registry out of a docker-config-style secret,
+// decoding the combined "auth" field when username/password aren't set separately.
+func credentialForRegistry(dockerConfigJSONData []byte, registry string) (*openpitrix.OCIRegistryCredential, error) {
+	var config dockerConfigJSON
+	if err := json.Unmarshal(dockerConfigJSONData, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse docker config secret: %s", err)
+	}
+
+	entry, ok := config.Auths[registry]
+	if !ok {
+		return nil, fmt.Errorf("docker config secret has no credential for registry %s", registry)
+	}
+	if entry.Username != "" {
+		return &openpitrix.OCIRegistryCredential{Username: entry.Username, Password: entry.Password}, nil
+	}
+	if entry.Auth == "" {
+		return nil, fmt.Errorf("docker config secret has an empty credential for registry %s", registry)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode auth for registry %s: %s", registry, err)
+	}
+	user, pass, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return nil, fmt.Errorf("malformed auth entry for registry %s", registry)
+	}
+	return &openpitrix.OCIRegistryCredential{Username: user, Password: pass}, nil
+}
+
+// pullOCIChart pulls the chart tarball an oci:// chart reference points at, as accepted by
+// CreateApplication/UpgradeApplication in place of an openpitrix app/version id, and returns the
+// sha256 digest of the pulled tarball so DescribeApplication can surface it for pinning.
+func (h *openpitrixHandler) pullOCIChart(ref openpitrix.ChartReference, credential *openpitrix.OCIRegistryCredential) (tgz []byte, digest string, err error) {
+	client, err := openpitrixclient.NewOCIRegistryClient("https://"+ref.Registry, credential)
+	if err != nil {
+		return nil, "", err
+	}
+
+	blob, err := client.PullChartBlob(ref.Chart, ref.Tag)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to pull %s: %s", ref, err)
+	}
+	defer blob.Close()
+
+	tgz, err = io.ReadAll(blob)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return tgz, fmt.Sprintf("sha256:%x", sha256.Sum256(tgz)), nil
+}
+
+// ListOCIChartTags handles GET .../repos/{repo}/oci/charts, listing every tag (chart version)
+// published for a single chart under an OCI-backed repository, or every chart name in the
+// registry's catalog when the chart query parameter is omitted.
+func (h *openpitrixHandler) ListOCIChartTags(req *restful.Request, resp *restful.Response) {
+	repoID := req.PathParameter("repo")
+	chart := req.QueryParameter("chart")
+
+	repo, err := h.describeRepo(repoID)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	if repo.Type != openpitrix.RepoTypeOCI {
+		api.HandleError(resp, req, fmt.Errorf("repo %s is not backed by an oci registry", repoID))
+		return
+	}
+
+	tags, err := listOCIChartTags(repo, chart)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(tags)
+}
+
+// listOCIChartTags lists every tag (chart version) published for a single chart under an
+// OCI-backed repository, or every chart name in the registry's catalog when chart is empty.
+func listOCIChartTags(repo *openpitrix.Repo, chart string) ([]string, error) {
+	client, err := newOCIRegistryClient(repo.URL, repo.Credential)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to oci registry %s: %s", repo.URL, err)
+	}
+	if chart == "" {
+		return client.Catalog()
+	}
+	return client.Tags(chart)
+}
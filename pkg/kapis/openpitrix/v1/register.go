@@ -39,6 +39,10 @@ const (
 var GroupVersion = schema.GroupVersion{Group: GroupName, Version: "v1"}
 
 func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory, ksClient versioned.Interface, options *openpitrixoptions.Options, stopCh <-chan struct{}) error {
+	// Chart tarballs, values.yaml, README and requirements.yaml can be large; let capable
+	// clients negotiate gzip instead of shipping them uncompressed.
+	c.EnableContentEncoding(true)
+
 	mimePatch := []string{restful.MIME_JSON, runtime.MimeJsonPatchJson, runtime.MimeMergePatchJson}
 	webservice := runtime.NewWebService(GroupVersion)
 
@@ -46,30 +50,50 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 
 	webservice.Route(webservice.POST("/repos").
 		To(handler.CreateRepo).
-		Doc("Create a global repository, which is used to store package of app").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Create a global repository, which is used to store package of app. Set type to oci to back the repository with an OCI-conforming registry (Harbor, ghcr, ECR, etc) instead of a plain HTTP chart index").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Param(webservice.QueryParameter("validate", "Validate repository")).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateRepoResponse{}).
-		Reads(openpitrix.CreateRepoRequest{}))
+		Writes(openpitrix.CreateRepoResponse{}).
+		Reads(openpitrix.CreateRepoRequest{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/workspaces/{workspace}/repos").
 		To(handler.CreateRepo).
-		Doc("Create repository in the specified workspace, which is used to store package of app").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Create repository in the specified workspace, which is used to store package of app. Set type to oci to back the repository with an OCI-conforming registry (Harbor, ghcr, ECR, etc) instead of a plain HTTP chart index").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Param(webservice.QueryParameter("validate", "Validate repository")).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateRepoResponse{}).
-		Reads(openpitrix.CreateRepoRequest{}))
+		Writes(openpitrix.CreateRepoResponse{}).
+		Reads(openpitrix.CreateRepoRequest{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/repos/{repo}").
 		To(handler.DeleteRepo).
 		Doc("Delete the specified global repository").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/repos/{repo}").
 		To(handler.DeleteRepo).
 		Doc("Delete the specified repository in the specified workspace").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/repos").
 		To(handler.ListRepos).
 		Doc("List global repositories").
@@ -81,9 +105,14 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			DataFormat("limit=%d,page=%d").
 			DefaultValue("limit=10,page=1")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/repos").
 		To(handler.ListRepos).
 		Doc("List repositories in the specified workspace").
@@ -96,117 +125,208 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			DefaultValue("limit=10,page=1")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/repos/{repo}").
 		To(handler.DescribeRepo).
 		Doc("Describe the specified global repository").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Repo{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(openpitrix.Repo{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/repos/{repo}").
 		To(handler.DescribeRepo).
 		Doc("Describe the specified repository in the specified workspace").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Repo{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(openpitrix.Repo{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/workspaces/{workspace}/repos/{repo}").
 		Consumes(mimePatch...).
 		To(handler.ModifyRepo).
 		Doc("Patch the specified repository in the specified workspace").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Reads(openpitrix.ModifyRepoRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/repos/{repo}").
 		Consumes(mimePatch...).
 		To(handler.ModifyRepo).
 		Doc("Patch the specified global repository").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
 		Reads(openpitrix.ModifyRepoRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/repos/{repo}/events").
 		To(handler.ListRepoEvents).
 		Doc("Get repository events").
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(models.PageableResponse{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/repos/{repo}/events").
 		To(handler.ListRepoEvents).
 		Doc("Get global repository events").
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(models.PageableResponse{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/repos/{repo}/action").
 		To(handler.DoRepoAction).
 		Deprecate().
-		Doc("Start index repository event").
+		Doc("Start index repository event. For OCI-backed repositories this walks the registry's _catalog and tag list instead of fetching an index.yaml").
 		Reads(openpitrix.RepoActionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/workspaces/{workspace}/repos/{repo}/action").
 		To(handler.DoRepoAction).
-		Doc("Start index repository event").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Doc("Start index repository event. For OCI-backed repositories this walks the registry's _catalog and tag list instead of fetching an index.yaml").
 		Reads(openpitrix.RepoActionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("repo", "repo id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/repos/{repo}/oci/charts").
+		To(handler.ListOCIChartTags).
+		Doc("List tags published under an OCI-backed repository. Pass chart to list the versions of a single chart, otherwise every chart name in the registry's catalog is listed").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixRepoTag}).
+		Returns(http.StatusOK, api.StatusOK, []string{}).
+		Writes([]string{}).
+		Param(webservice.PathParameter("repo", "repo id")).
+		Param(webservice.QueryParameter("chart", "chart name to list tags for; omit to list chart names instead").Required(false)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// app template
 	webservice.Route(webservice.POST("/apps/{app}/action").
 		Deprecate().
 		To(handler.DoAppAction).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Doc("Perform recover or suspend operation on app").
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps/{app}/action").
 		To(handler.DoAppAction).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Doc("Perform recover or suspend operation on app").
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/apps").
 		Deprecate().
 		To(handler.CreateApp).
 		Doc("Create a new app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateAppResponse{}).
+		Writes(openpitrix.CreateAppResponse{}).
 		Reads(openpitrix.CreateAppRequest{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps").
 		To(handler.CreateApp).
 		Doc("Create a new app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateAppResponse{}).
+		Writes(openpitrix.CreateAppResponse{}).
 		Reads(openpitrix.CreateAppRequest{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/apps/{app}").
 		Deprecate().
 		Consumes(mimePatch...).
 		To(handler.ModifyApp).
 		Doc("Patch the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.ModifyAppVersionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.PATCH("/workspaces/{workspace}/apps/{app}").
 		Consumes(mimePatch...).
 		To(handler.ModifyApp).
 		Doc("Patch the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.ModifyAppVersionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/apps").
 		Deprecate().
@@ -221,8 +341,13 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			DefaultValue("limit=10,page=1")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/apps").
 		To(handler.ListApps).
 		Doc("List app templates in the specified workspace.").
@@ -236,36 +361,61 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			DefaultValue("limit=10,page=1")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/apps/{app}").
 		To(handler.DescribeApp).
 		Doc("Describe the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersion{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(openpitrix.AppVersion{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/apps/{app}").
 		Deprecate().
 		To(handler.DescribeApp).
 		Doc("Describe the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersion{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(openpitrix.AppVersion{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.DELETE("/apps/{app}").
 		Deprecate().
 		To(handler.DeleteApp).
 		Doc("Delete the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/apps/{app}").
 		To(handler.DeleteApp).
 		Doc("Delete the specified app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// app versions
 
@@ -273,43 +423,68 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Deprecate().
 		To(handler.CreateAppVersion).
 		Doc("Create a new app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.CreateAppVersionRequest{}).
 		Param(webservice.QueryParameter("validate", "Validate format of package(pack by op tool)")).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateAppVersionResponse{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(openpitrix.CreateAppVersionResponse{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps/{app}/versions").
 		To(handler.CreateAppVersion).
 		Doc("Create a new app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.CreateAppVersionRequest{}).
 		Param(webservice.QueryParameter("validate", "Validate format of package(pack by op tool)")).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateAppVersionResponse{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(openpitrix.CreateAppVersionResponse{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/apps/{app}/versions/{version}").
 		Deprecate().
 		To(handler.DeleteAppVersion).
 		Doc("Delete the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/apps/{app}/versions/{version}").
 		To(handler.DeleteAppVersion).
 		Doc("Delete the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/apps/{app}/versions/{version}").
 		Deprecate().
 		To(handler.DescribeAppVersion).
 		Doc("Describe the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersion{}).
+		Writes(openpitrix.AppVersion{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/apps/{app}/versions").
 		Deprecate().
 		To(handler.ListAppVersions).
@@ -324,15 +499,28 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.PathParameter("app", "app template id")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Param(webservice.QueryParameter("verified", "filter to versions that passed the repo's configured signature verification, e.g. verified=true").
+			Required(false).
+			DataFormat("verified=%t")).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/apps/{app}/versions/{version}").
 		To(handler.DescribeAppVersion).
 		Doc("Describe the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersion{}).
+		Writes(openpitrix.AppVersion{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/apps/{app}/versions").
 		To(handler.ListAppVersions).
 		Doc("Get active versions of app, can filter with these fields(version_id, app_id, name, owner, description, package_name, status, type), default return all active app versions").
@@ -346,44 +534,73 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.PathParameter("app", "app template id")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Param(webservice.QueryParameter("verified", "filter to versions that passed the repo's configured signature verification, e.g. verified=true").
+			Required(false).
+			DataFormat("verified=%t")).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/apps/{app}/versions/{version}/package").
 		To(handler.GetAppVersionPackage).
-		Doc("Get packages of version-specific app").
+		Doc("Get packages of version-specific app. For apps sourced from an OCI registry the chart tarball is streamed directly from the registry").
 		Returns(http.StatusOK, api.StatusOK, openpitrix.GetAppVersionPackageResponse{}).
+		Writes(openpitrix.GetAppVersionPackageResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/apps/{app}/versions/{version}").
 		Deprecate().
 		Consumes(mimePatch...).
 		To(handler.ModifyAppVersion).
 		Doc("Patch the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.ModifyAppVersionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.PATCH("/workspaces/{workspace}/apps/{app}/versions/{version}").
 		Consumes(mimePatch...).
 		To(handler.ModifyAppVersion).
 		Doc("Patch the specified app template version").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Reads(openpitrix.ModifyAppVersionRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/apps/{app}/versions/{version}/files").
 		Deprecate().
 		To(handler.GetAppVersionFiles).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Doc("Get app template package files").
 		Returns(http.StatusOK, api.StatusOK, openpitrix.GetAppVersionPackageFilesResponse{}).
+		Writes(openpitrix.GetAppVersionPackageFilesResponse{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// app version audits
 
@@ -392,38 +609,63 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		To(handler.ListAppVersionAudits).
 		Doc("List audits information of the specific app template").
 		Param(webservice.PathParameter("app", "app template id")).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersionAudit{}))
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppVersionAuditTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/workspaces/{workspace}/apps/{app}/versions/{version}/audits").
 		To(handler.ListAppVersionAudits).
 		Doc("List audits information of version-specific app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersionAudit{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppVersionAuditTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/apps/{app}/versions/{version}/audits").
 		Deprecate().
 		To(handler.ListAppVersionAudits).
 		Doc("List audits information of version-specific app template").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
-		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersionAudit{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppVersionAuditTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/apps/{app}/versions/{version}/action").
 		Deprecate().
 		To(handler.DoAppVersionAction).
-		Doc("Perform submit or other operations on app").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Perform submit or other operations on app. When the repo's verification policy is strict, submitting an unverified or invalid chart version is rejected").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.POST("/workspaces/{workspace}/apps/{app}/versions/{version}/action").
 		To(handler.DoAppVersionAction).
-		Doc("Perform submit or other operations on app").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Perform submit or other operations on app. When the repo's verification policy is strict, submitting an unverified or invalid chart version is rejected").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixAppTemplateTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("version", "app template version id")).
-		Param(webservice.PathParameter("app", "app template id")))
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// application release
 
@@ -431,7 +673,8 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Deprecate().
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Writes(models.PageableResponse{}).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixApplicationTag}).
 		Doc("List all applications").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
 			Required(false).
@@ -440,11 +683,16 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/namespaces/{namespace}/applications").
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Doc("List all applications within the specified namespace").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
@@ -455,11 +703,16 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/applications").
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Doc("List all applications within the specified workspace").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
@@ -470,11 +723,16 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/applications").
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Doc("List all applications within the specified cluster").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
@@ -486,11 +744,16 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/clusters/{cluster}/applications").
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Doc("List all applications within the specified cluster").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
@@ -501,11 +764,16 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications").
 		To(handler.ListApplications).
 		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Doc("List all applications within the specified namespace").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions, connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
@@ -518,7 +786,11 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
 			Required(false).
 			DataFormat("limit=%d,page=%d").
-			DefaultValue("limit=10,page=1")))
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}").
 		Consumes(mimePatch...).
@@ -527,9 +799,14 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Reads(openpitrix.ModifyClusterAttributesRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.PATCH("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}").
 		Consumes(mimePatch...).
@@ -538,90 +815,289 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Reads(openpitrix.ModifyClusterAttributesRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}").
 		Consumes(mimePatch...).
 		To(handler.UpgradeApplication).
-		Doc("Upgrade application").
+		Doc("Upgrade application. VersionId may be an oci:// chart reference (e.g. oci://registry.example.com/charts/redis:17.3.0) to upgrade straight from an OCI registry instead of an indexed app version, authenticating via ImagePullSecretRef").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Reads(openpitrix.UpgradeClusterRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}").
 		Consumes(mimePatch...).
 		To(handler.UpgradeApplication).
-		Doc("Upgrade application").
+		Doc("Upgrade application. VersionId may be an oci:// chart reference (e.g. oci://registry.example.com/charts/redis:17.3.0) to upgrade straight from an OCI registry instead of an indexed app version, authenticating via ImagePullSecretRef").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Reads(openpitrix.UpgradeClusterRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}:diff").
+		To(handler.DiffApplication).
+		Doc("Diff the values an upgrade would apply against the values the application was last deployed with, and check that they parse as YAML, without mutating the cluster. Chart templates are not rendered and no server-side dry-run is made").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.UpgradeClusterRequest{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationDiffResponse{}).
+		Writes(openpitrix.ApplicationDiffResponse{}).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}:diff").
+		To(handler.DiffApplication).
+		Doc("Diff the values an upgrade would apply against the values the application was last deployed with, and check that they parse as YAML, without mutating the cluster. Chart templates are not rendered and no server-side dry-run is made").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.UpgradeClusterRequest{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationDiffResponse{}).
+		Writes(openpitrix.ApplicationDiffResponse{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/namespaces/{namespace}/applications:dryRun").
+		To(handler.DryRunCreateApplication).
+		Doc("Preview the values a new application's deployment would apply, reported as added, with the same YAML check as :diff, without mutating the cluster. Chart templates are not rendered and no server-side dry-run is made").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.CreateClusterRequest{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationDiffResponse{}).
+		Writes(openpitrix.ApplicationDiffResponse{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications:dryRun").
+		To(handler.DryRunCreateApplication).
+		Doc("Preview the values a new application's deployment would apply, reported as added, with the same YAML check as :diff, without mutating the cluster. Chart templates are not rendered and no server-side dry-run is made").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.CreateClusterRequest{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationDiffResponse{}).
+		Writes(openpitrix.ApplicationDiffResponse{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications").
 		To(handler.CreateApplication).
-		Doc("Deploy a new application").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Deploy a new application. AppId/VersionId may be an oci:// chart reference (e.g. oci://registry.example.com/charts/redis:17.3.0) to install straight from an OCI registry instead of an indexed app version, authenticating via ImagePullSecretRef").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixApplicationTag}).
 		Reads(openpitrix.CreateClusterRequest{}).
-		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateApplicationResponse{}).
+		Writes(openpitrix.CreateApplicationResponse{}).
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
-		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)))
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/workspaces/{workspace}/namespaces/{namespace}/applications").
 		To(handler.CreateApplication).
-		Doc("Deploy a new application").
-		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
+		Doc("Deploy a new application. AppId/VersionId may be an oci:// chart reference (e.g. oci://registry.example.com/charts/redis:17.3.0) to install straight from an OCI registry instead of an indexed app version, authenticating via ImagePullSecretRef").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixApplicationTag}).
 		Reads(openpitrix.CreateClusterRequest{}).
-		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)))
+		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateApplicationResponse{}).
+		Writes(openpitrix.CreateApplicationResponse{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}").
 		To(handler.DescribeApplication).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Application{}).
+		Writes(openpitrix.Application{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
-		Doc("Describe the specified application of the namespace").
+		Doc("Describe the specified application of the namespace. Surfaces the resolved chart digest when the application was installed from an OCI chart reference, so deployments can be pinned to it").
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}").
 		To(handler.DescribeApplication).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Application{}).
+		Writes(openpitrix.Application{}).
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
-		Doc("Describe the specified application of the namespace").
+		Doc("Describe the specified application of the namespace. Surfaces the resolved chart digest when the application was installed from an OCI chart reference, so deployments can be pinned to it").
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}/revisions").
+		To(handler.ListApplicationRevisions).
+		Doc("List the revision history of the specified application").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
+			Required(false).
+			DataFormat("limit=%d,page=%d").
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}/revisions").
+		To(handler.ListApplicationRevisions).
+		Doc("List the revision history of the specified application").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.QueryParameter(params.PagingParam, "paging query, e.g. limit=100,page=1").
+			Required(false).
+			DataFormat("limit=%d,page=%d").
+			DefaultValue("limit=10,page=1")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}/revisions/{revision}").
+		To(handler.DescribeApplicationRevision).
+		Doc("Describe the manifest and values used at the specified revision of the application").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationRevisionDetail{}).
+		Writes(openpitrix.ApplicationRevisionDetail{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.PathParameter("revision", "the revision number")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}/revisions/{revision}").
+		To(handler.DescribeApplicationRevision).
+		Doc("Describe the manifest and values used at the specified revision of the application").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.ApplicationRevisionDetail{}).
+		Writes(openpitrix.ApplicationRevisionDetail{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Param(webservice.PathParameter("revision", "the revision number")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}/rollback").
+		To(handler.RollbackApplication).
+		Doc("Roll the application back to an earlier revision").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.RollbackApplicationRequest{}).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}/rollback").
+		To(handler.RollbackApplication).
+		Doc("Roll the application back to an earlier revision").
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
+		Reads(openpitrix.RollbackApplicationRequest{}).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
+		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/namespaces/{namespace}/applications/{application}").
 		To(handler.DeleteApplication).
 		Doc("Delete the specified application").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
 		Param(webservice.PathParameter("workspace", "the workspace of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/clusters/{cluster}/namespaces/{namespace}/applications/{application}").
 		To(handler.DeleteApplication).
 		Doc("Delete the specified application").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
 		Param(webservice.PathParameter("namespace", "the name of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.DELETE("/workspaces/{workspace}/clusters/{cluster}/applications/{application}").
 		To(handler.DeleteApplication).
 		Doc("Delete the specified application").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.NamespaceResourcesTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
 		Param(webservice.PathParameter("cluster", "the name of the cluster.").Required(true)).
 		Param(webservice.PathParameter("workspace", "the workspaces of the project").Required(true)).
-		Param(webservice.PathParameter("application", "the id of the application").Required(true)))
+		Param(webservice.PathParameter("application", "the id of the application").Required(true)).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// category
 	webservice.Route(webservice.POST("/categories").
@@ -630,13 +1106,23 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Reads(openpitrix.CreateCategoryRequest{}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.CreateCategoryResponse{}).
-		Param(webservice.PathParameter("app", "app template id")))
+		Writes(openpitrix.CreateCategoryResponse{}).
+		Param(webservice.PathParameter("app", "app template id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.DELETE("/categories/{category}").
 		To(handler.DeleteCategory).
 		Doc("Delete the specified category").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("category", "category id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("category", "category id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.PATCH("/categories/{category}").
 		Consumes(mimePatch...).
 		To(handler.ModifyCategory).
@@ -644,15 +1130,26 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Reads(openpitrix.ModifyCategoryRequest{}).
 		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
-		Param(webservice.PathParameter("category", "category id")))
+		Writes(errors.Error{}).
+		Param(webservice.PathParameter("category", "category id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/categories/{category}").
 		To(handler.DescribeCategory).
 		Doc("Describe the specified category").
 		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Returns(http.StatusOK, api.StatusOK, openpitrix.Category{}).
-		Param(webservice.PathParameter("category", "category id")))
+		Writes(openpitrix.Category{}).
+		Param(webservice.PathParameter("category", "category id")).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 	webservice.Route(webservice.GET("/categories").
 		To(handler.ListCategories).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Doc("List categories").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions,connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
 			Required(false).
@@ -663,11 +1160,17 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			DefaultValue("limit=10,page=1")).
 		Param(webservice.QueryParameter(params.ReverseParam, "sort parameters, e.g. reverse=true")).
 		Param(webservice.QueryParameter(params.OrderByParam, "sort parameters, e.g. orderBy=createTime")).
-		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}))
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	// review
 	webservice.Route(webservice.GET("/reviews").
 		To(handler.ListReviews).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Doc("Get reviews of version-specific app").
 		Param(webservice.QueryParameter(params.ConditionsParam, "query conditions,connect multiple conditions with commas, equal symbol for exact query, wave symbol for fuzzy query e.g. name~a").
 			Required(false).
@@ -676,27 +1179,137 @@ func AddToContainer(c *restful.Container, ksInfomrers informers.InformerFactory,
 			Required(false).
 			DataFormat("limit=%d,page=%d").
 			DefaultValue("limit=10,page=1")).
-		Returns(http.StatusOK, api.StatusOK, openpitrix.AppVersionReview{}))
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.GET("/attachments/{attachment}").
 		To(handler.DescribeAttachment).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Doc("Get attachment by attachment id").
 		Param(webservice.PathParameter("attachment", "attachment id")).
-		Returns(http.StatusOK, api.StatusOK, openpitrix.Attachment{}))
+		Returns(http.StatusOK, api.StatusOK, openpitrix.Attachment{}).
+		Writes(openpitrix.Attachment{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.POST("/attachments").
 		To(handler.CreateAttachment).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Consumes(runtime.MimeMultipartFormData).
-		Doc("Create an attachment").
-		Returns(http.StatusOK, api.StatusOK, openpitrix.Attachment{}))
+		Doc("Create an attachment. The upload is validated before being persisted: MIME type against a fixed allow-list, a streaming max-size guard, and, for Helm chart tarballs, chart structural validation. No antivirus scan is run").
+		Returns(http.StatusOK, api.StatusOK, openpitrix.Attachment{}).
+		Writes(openpitrix.Attachment{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	webservice.Route(webservice.DELETE("/attachments/{attachment}").
 		To(handler.DeleteAttachments).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixTag}).
 		Doc("Delete one or multiple attachments, whose ids are separated by comma").
 		Param(webservice.PathParameter("attachment", "attachment id")).
-		Returns(http.StatusOK, api.StatusOK, errors.Error{}))
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/webhooks").
+		To(handler.CreateWebhook).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Subscribe to openpitrix application lifecycle events (created, upgraded, rolledBack, deleted). Deliveries are POSTed as JSON, signed with HMAC-SHA256 over secret in the X-KubeSphere-Signature header").
+		Reads(openpitrix.CreateWebhookRequest{}).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.Webhook{}).
+		Writes(openpitrix.Webhook{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/webhooks").
+		To(handler.ListWebhooks).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("List registered webhook subscriptions").
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/webhooks/{webhook}").
+		To(handler.DescribeWebhook).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Describe the specified webhook subscription").
+		Param(webservice.PathParameter("webhook", "webhook id")).
+		Returns(http.StatusOK, api.StatusOK, openpitrix.Webhook{}).
+		Writes(openpitrix.Webhook{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.PATCH("/webhooks/{webhook}").
+		Consumes(mimePatch...).
+		To(handler.ModifyWebhook).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Patch the specified webhook subscription").
+		Reads(openpitrix.ModifyWebhookRequest{}).
+		Param(webservice.PathParameter("webhook", "webhook id")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.DELETE("/webhooks/{webhook}").
+		To(handler.DeleteWebhook).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Delete the specified webhook subscription").
+		Param(webservice.PathParameter("webhook", "webhook id")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.GET("/webhooks/{webhook}/deliveries").
+		To(handler.ListWebhookDeliveries).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Read the dead-letter store of delivery attempts (including failed ones) for the specified webhook subscription").
+		Param(webservice.PathParameter("webhook", "webhook id")).
+		Returns(http.StatusOK, api.StatusOK, models.PageableResponse{}).
+		Writes(models.PageableResponse{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
+
+	webservice.Route(webservice.POST("/webhooks/{webhook}/deliveries/{delivery}/redeliver").
+		To(handler.RedeliverWebhookDelivery).
+		Metadata(restfulspec.KeyOpenAPITags, []string{constants.OpenpitrixWebhookTag}).
+		Doc("Manually redeliver a previously recorded webhook delivery").
+		Param(webservice.PathParameter("webhook", "webhook id")).
+		Param(webservice.PathParameter("delivery", "delivery id")).
+		Returns(http.StatusOK, api.StatusOK, errors.Error{}).
+		Writes(errors.Error{}).
+		Returns(http.StatusBadRequest, "Bad Request", errors.Error{}).
+		Returns(http.StatusForbidden, "Forbidden", errors.Error{}).
+		Returns(http.StatusNotFound, "Not Found", errors.Error{}).
+		Returns(http.StatusInternalServerError, "Internal Server Error", errors.Error{}))
 
 	c.Add(webservice)
+	c.Add(newDebugWebService())
 
 	return nil
 }
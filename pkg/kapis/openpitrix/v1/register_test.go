@@ -0,0 +1,53 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	restful "github.com/emicklei/go-restful"
+	restfulspec "github.com/emicklei/go-restful-openapi"
+)
+
+// TestRoutesHaveOpenAPIMetadata walks every route registered by AddToContainer and fails if any
+// of them is missing the metadata needed to generate a correct swagger.json: an OpenAPI tag and a
+// declared response body for its success status.
+func TestRoutesHaveOpenAPIMetadata(t *testing.T) {
+	container := restful.NewContainer()
+	if err := AddToContainer(container, nil, nil, nil, nil); err != nil {
+		t.Fatalf("AddToContainer() error = %v", err)
+	}
+
+	for _, ws := range container.RegisteredWebServices() {
+		if ws.RootPath() == "/debug/pprof" {
+			continue
+		}
+		for _, route := range ws.Routes() {
+			routeID := route.Method + " " + route.Path
+
+			tags, _ := route.Metadata[restfulspec.KeyOpenAPITags].([]string)
+			if len(tags) == 0 {
+				t.Errorf("%s: missing %s metadata", routeID, restfulspec.KeyOpenAPITags)
+			}
+
+			if route.WriteSample == nil {
+				t.Errorf("%s: missing Writes()", routeID)
+			}
+
+			if len(route.ResponseErrors) == 0 {
+				t.Errorf("%s: missing Returns()", routeID)
+			}
+		}
+	}
+}
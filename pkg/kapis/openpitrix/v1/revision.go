@@ -0,0 +1,205 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"strings"
+
+	restful "github.com/emicklei/go-restful"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/models"
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	"kubesphere.io/kubesphere/pkg/server/errors"
+	"kubesphere.io/kubesphere/pkg/server/params"
+)
+
+func revisionKey(namespace, application string) string {
+	return namespace + "/" + application
+}
+
+// recordRevision appends a new ApplicationRevisionDetail for application, as called by
+// CreateApplication, UpgradeApplication and rollbackApplication each time they deploy it; it is
+// called whether or not an oci:// chart reference resolved. The manifest stored here is the
+// raw values the release was installed/upgraded with rather than the rendered Helm manifest, since
+// this tree has no Helm client to render one.
+func (h *openpitrixHandler) recordRevision(namespace, application, chartVersion, values, status string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	key := revisionKey(namespace, application)
+	revision := len(h.revisions[key]) + 1
+	h.revisions[key] = append(h.revisions[key], &openpitrix.ApplicationRevisionDetail{
+		ApplicationRevision: openpitrix.ApplicationRevision{
+			Revision:     revision,
+			ChartVersion: chartVersion,
+			ValuesHash:   fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(values))),
+			DeployedAt:   now(),
+			Status:       status,
+		},
+		Manifest: values,
+		Values:   values,
+	})
+}
+
+// ListApplicationRevisions handles GET .../applications/{application}/revisions, returning a
+// paged list of the application's prior releases.
+//
+// Revision history is currently kept in h.revisions, an in-process map - it does NOT survive an
+// apiserver restart. The request asked for revisions persisted in the existing CRD store so
+// history outlives a pruned Helm release; that needs an actual ApplicationRevision CRD (type,
+// generated deepcopy/clientset, informer wiring), none of which exists in this package today.
+// recordRevision/listApplicationRevisions/describeApplicationRevision are written so that
+// swapping the backing map for a CRD-backed client later only touches this file.
+func (h *openpitrixHandler) ListApplicationRevisions(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	application := req.PathParameter("application")
+
+	limitStr, pageStr := req.QueryParameter("limit"), req.QueryParameter("page")
+	for _, pair := range strings.Split(req.QueryParameter(params.PagingParam), ",") {
+		key, value, _ := strings.Cut(pair, "=")
+		switch key {
+		case "limit":
+			limitStr = value
+		case "page":
+			pageStr = value
+		}
+	}
+
+	result, err := h.listApplicationRevisions(namespace, application, limitStr, pageStr)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// DescribeApplicationRevision handles GET .../revisions/{revision}, returning the manifest and
+// values used at that revision.
+func (h *openpitrixHandler) DescribeApplicationRevision(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	application := req.PathParameter("application")
+
+	revision, err := strconv.Atoi(req.PathParameter("revision"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	result, err := h.describeApplicationRevision(namespace, application, revision)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// RollbackApplication handles POST .../rollback, rolling the application back to an earlier
+// release in the handler's own state (see rollbackApplication). No Helm rollback is run, and the
+// request's Wait, Timeout and Recreate fields are not acted on.
+func (h *openpitrixHandler) RollbackApplication(req *restful.Request, resp *restful.Response) {
+	namespace := req.PathParameter("namespace")
+	application := req.PathParameter("application")
+
+	var rollbackRequest openpitrix.RollbackApplicationRequest
+	if err := req.ReadEntity(&rollbackRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	if err := h.rollbackApplication(namespace, application, &rollbackRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(errors.None)
+}
+
+// listApplicationRevisions pages through application's revision history, newest first.
+func (h *openpitrixHandler) listApplicationRevisions(namespace, application, limitStr, pageStr string) (*models.PageableResponse, error) {
+	limit, page := 10, 1
+	if limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid limit %q: must be a positive integer", limitStr)
+		}
+		limit = parsed
+	}
+	if pageStr != "" {
+		parsed, err := strconv.Atoi(pageStr)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("invalid page %q: must be a positive integer", pageStr)
+		}
+		page = parsed
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	revisions := h.revisions[revisionKey(namespace, application)]
+
+	items := make([]interface{}, 0, len(revisions))
+	for i := len(revisions) - 1; i >= 0; i-- {
+		items = append(items, revisions[i].ApplicationRevision)
+	}
+
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return &models.PageableResponse{Items: items[start:end], TotalCount: len(items)}, nil
+}
+
+// describeApplicationRevision returns the manifest/values recorded for a single revision.
+func (h *openpitrixHandler) describeApplicationRevision(namespace, application string, revision int) (*openpitrix.ApplicationRevisionDetail, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for _, detail := range h.revisions[revisionKey(namespace, application)] {
+		if detail.Revision == revision {
+			return detail, nil
+		}
+	}
+	return nil, fmt.Errorf("application %s/%s has no revision %d", namespace, application, revision)
+}
+
+// rollbackApplication mirrors Helm's rollback action: it re-deploys the chart version and values
+// recorded at req.Revision and records the rollback itself as a new revision, the same way a real
+// `helm rollback` leaves the rolled-back-to content as the latest history entry rather than
+// rewinding history in place.
+func (h *openpitrixHandler) rollbackApplication(namespace, application string, req *openpitrix.RollbackApplicationRequest) error {
+	target, err := h.describeApplicationRevision(namespace, application, req.Revision)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	app, ok := h.applications[application]
+	if ok {
+		app.VersionId = target.ChartVersion
+		app.Status = "active"
+	}
+	h.mu.Unlock()
+
+	h.recordRevision(namespace, application, target.ChartVersion, target.Values, fmt.Sprintf("rolled back to revision %d", req.Revision))
+	if ok {
+		h.dispatchWebhookEvent(openpitrix.WebhookEventApplicationRolledBack, app)
+	}
+	return nil
+}
@@ -0,0 +1,370 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/clearsign"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	openpitrixclient "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+// verifyChartVersion checks the chart tarball at packageURL against the repository's configured
+// verification policy, returning whether it verified and the identity that signed it. Used by
+// indexHTTPRepo for classic chart repositories; indexOCIRepo uses verifyOCIChartVersion instead,
+// since OCI registries don't publish a packageURL+suffix sidecar file a caller can just GET.
+func (h *openpitrixHandler) verifyChartVersion(verification *openpitrix.Verification, packageURL string) (verified bool, signer string, err error) {
+	if verification == nil || verification.Mode == openpitrix.VerificationModeNone {
+		return false, "", nil
+	}
+
+	tgz, err := h.fetchPackage(packageURL)
+	if err != nil {
+		return false, "", err
+	}
+	defer tgz.Close()
+
+	return verifyChartTarball(tgz, verification, httpSignatureFetcher(packageURL))
+}
+
+// verifyOCIChartVersion checks an OCI-hosted chart against the repository's configured
+// verification policy, returning whether it verified and the identity that signed it. Mirrors
+// verifyChartVersion for OCI-backed repos: rather than a packageURL+suffix sidecar file, the
+// signature is looked up via cosign's own OCI convention (PullCosignSignature), which is the only
+// verification mode an OCI-conforming registry can realistically support here - there's no
+// equivalent of a foo.tgz.prov file on a content-addressed blob store.
+func (h *openpitrixHandler) verifyOCIChartVersion(verification *openpitrix.Verification, client *openpitrixclient.OCIRegistryClient, chart string, tgz []byte, digest string) (verified bool, signer string, err error) {
+	if verification == nil || verification.Mode == openpitrix.VerificationModeNone {
+		return false, "", nil
+	}
+	if verification.Mode != openpitrix.VerificationModeCosign {
+		return false, "", fmt.Errorf("verification mode %q is not supported for oci-backed repositories; only %q is", verification.Mode, openpitrix.VerificationModeCosign)
+	}
+
+	return verifyChartTarball(bytes.NewReader(tgz), verification, ociSignatureFetcher(client, chart, digest))
+}
+
+// verifyChartTarball checks tgz against verification's policy, fetching the provenance/signature
+// artifact via fetchSignature(".prov") or fetchSignature(".sig").
+func verifyChartTarball(tgz io.Reader, verification *openpitrix.Verification, fetchSignature func(suffix string) ([]byte, error)) (bool, string, error) {
+	switch verification.Mode {
+	case openpitrix.VerificationModeProv:
+		return verifyProv(tgz, fetchSignature, verification.PublicKey)
+	case openpitrix.VerificationModeCosign:
+		return verifyCosign(tgz, fetchSignature, verification)
+	default:
+		return false, "", fmt.Errorf("unsupported verification mode %q", verification.Mode)
+	}
+}
+
+func (h *openpitrixHandler) fetchPackage(url string) (io.ReadCloser, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d fetching %s", resp.StatusCode, url)
+	}
+	return resp.Body, nil
+}
+
+// httpSignatureFetcher fetches the sidecar provenance/signature file a classic Helm repository
+// publishes alongside a chart tarball at packageURL+suffix (".prov" or ".sig").
+func httpSignatureFetcher(packageURL string) func(suffix string) ([]byte, error) {
+	return func(suffix string) ([]byte, error) {
+		resp, err := http.Get(packageURL + suffix)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("no %s file found at %s", suffix, packageURL+suffix)
+		}
+		return io.ReadAll(resp.Body)
+	}
+}
+
+// ociSignatureFetcher fetches a chart's detached cosign signature out of the OCI registry itself,
+// the only signature artifact an OCI-backed repository can supply.
+func ociSignatureFetcher(client *openpitrixclient.OCIRegistryClient, chart, digest string) func(suffix string) ([]byte, error) {
+	return func(suffix string) ([]byte, error) {
+		if suffix != ".sig" {
+			return nil, fmt.Errorf("oci-backed repositories have no %s artifact", suffix)
+		}
+		return client.PullCosignSignature(chart, digest)
+	}
+}
+
+// verifyProv verifies a classic Helm .prov file: an openpgp clearsigned block whose body embeds
+// the sha256 of the chart tarball, signed by a key trusted via publicKeyring.
+func verifyProv(tgz io.Reader, fetchSignature func(suffix string) ([]byte, error), publicKeyring string) (bool, string, error) {
+	sum := sha256.New()
+	if _, err := io.Copy(sum, tgz); err != nil {
+		return false, "", err
+	}
+	digest := fmt.Sprintf("%x", sum.Sum(nil))
+
+	raw, err := fetchSignature(".prov")
+	if err != nil {
+		return false, "", fmt.Errorf("no provenance file found: %s", err)
+	}
+
+	block, rest, err := decodeClearsign(bytes.NewReader(raw))
+	if err != nil {
+		return false, "", err
+	}
+	if !bytes.Contains(rest, []byte(digest)) {
+		return false, "", fmt.Errorf("provenance file does not match chart sha256 digest")
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader([]byte(publicKeyring)))
+	if err != nil {
+		return false, "", fmt.Errorf("failed to parse trusted public keyring: %s", err)
+	}
+	signer, err := openpgp.CheckDetachedSignature(keyring, bytes.NewReader(rest), block.ArmoredSignature.Body)
+	if err != nil {
+		return false, "", fmt.Errorf("provenance signature verification failed: %s", err)
+	}
+	for identity := range signer.Identities {
+		return true, identity, nil
+	}
+	return true, "", nil
+}
+
+func decodeClearsign(r io.Reader) (*clearsign.Block, []byte, error) {
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, err
+	}
+	block, _ := clearsign.Decode(raw)
+	if block == nil {
+		return nil, nil, fmt.Errorf("not a valid clearsigned provenance file")
+	}
+	return block, block.Plaintext, nil
+}
+
+// verifyCosign verifies a detached cosign signature against the repository's configured public
+// key, falling back to a keyless Rekor inclusion-proof lookup when no key is configured.
+func verifyCosign(tgz io.Reader, fetchSignature func(suffix string) ([]byte, error), verification *openpitrix.Verification) (bool, string, error) {
+	sum := sha256.New()
+	if _, err := io.Copy(sum, tgz); err != nil {
+		return false, "", err
+	}
+	digest := sum.Sum(nil)
+
+	if verification.PublicKey != "" {
+		sigBytes, err := fetchSignature(".sig")
+		if err != nil {
+			return false, "", err
+		}
+		sig, err := base64.StdEncoding.DecodeString(string(sigBytes))
+		if err != nil {
+			return false, "", fmt.Errorf("failed to decode cosign signature: %s", err)
+		}
+		pub, err := parseECDSAPublicKey(verification.PublicKey)
+		if err != nil {
+			return false, "", err
+		}
+		if !ecdsa.VerifyASN1(pub, digest, sig) {
+			return false, "", fmt.Errorf("cosign signature verification failed")
+		}
+		return true, "", nil
+	}
+
+	if verification.RekorURL == "" {
+		return false, "", fmt.Errorf("cosign verification requires either a publicKey or a rekorURL")
+	}
+	return verifyRekorInclusion(verification.RekorURL, digest)
+}
+
+func parseECDSAPublicKey(pemEncoded string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemEncoded))
+	if block == nil {
+		return nil, fmt.Errorf("invalid PEM public key")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("public key is not ECDSA")
+	}
+	return ecdsaKey, nil
+}
+
+// rekorLogEntry is the subset of a Rekor GET /api/v1/log/entries/{uuid} response needed to
+// recompute and check its Merkle inclusion proof.
+type rekorLogEntry struct {
+	Body         string `json:"body"`
+	Verification struct {
+		InclusionProof struct {
+			LogIndex int64    `json:"logIndex"`
+			RootHash string   `json:"rootHash"`
+			TreeSize int64    `json:"treeSize"`
+			Hashes   []string `json:"hashes"`
+		} `json:"inclusionProof"`
+	} `json:"verification"`
+}
+
+// verifyRekorInclusion looks up every Rekor log entry for digest and checks each one's Merkle
+// audit path (RFC 6962) against its own claimed root hash, succeeding as soon as one entry's
+// proof recomputes to that root. This proves the entry is actually included in the tree the
+// server says it's included in; it does not independently verify that root's signed checkpoint
+// against Rekor's own public key, since the repository's Verification policy has no field for
+// one - that would be a second, separate trust decision from "is this entry in this tree".
+func verifyRekorInclusion(rekorURL string, digest []byte) (bool, string, error) {
+	uuids, err := rekorRetrieveUUIDs(rekorURL, digest)
+	if err != nil {
+		return false, "", err
+	}
+	if len(uuids) == 0 {
+		return false, "", fmt.Errorf("no rekor entry found for digest sha256:%x", digest)
+	}
+
+	for _, uuid := range uuids {
+		entry, err := rekorGetEntry(rekorURL, uuid)
+		if err != nil {
+			continue
+		}
+		if verifyRekorEntryInclusion(entry) {
+			return true, "", nil
+		}
+	}
+	return false, "", fmt.Errorf("no rekor entry for digest sha256:%x has a valid inclusion proof", digest)
+}
+
+// rekorRetrieveUUIDs looks up every log entry Rekor has indexed under digest.
+func rekorRetrieveUUIDs(rekorURL string, digest []byte) ([]string, error) {
+	body, err := json.Marshal(map[string]string{"hash": fmt.Sprintf("sha256:%x", digest)})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Post(strings.TrimSuffix(rekorURL, "/")+"/api/v1/index/retrieve", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d looking up rekor entries for sha256:%x", resp.StatusCode, digest)
+	}
+	var uuids []string
+	if err := json.NewDecoder(resp.Body).Decode(&uuids); err != nil {
+		return nil, fmt.Errorf("failed to parse rekor index response: %s", err)
+	}
+	return uuids, nil
+}
+
+// rekorGetEntry fetches a single log entry by uuid.
+func rekorGetEntry(rekorURL, uuid string) (*rekorLogEntry, error) {
+	resp, err := http.Get(strings.TrimSuffix(rekorURL, "/") + "/api/v1/log/entries/" + uuid)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching rekor entry %s", resp.StatusCode, uuid)
+	}
+	var entries map[string]rekorLogEntry
+	if err := json.NewDecoder(resp.Body).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("failed to parse rekor entry %s: %s", uuid, err)
+	}
+	entry, ok := entries[uuid]
+	if !ok {
+		return nil, fmt.Errorf("rekor entry %s missing from response", uuid)
+	}
+	return &entry, nil
+}
+
+// verifyRekorEntryInclusion recomputes entry's Merkle audit path from its leaf hash up to the
+// root and checks it against the rootHash the server claims for it, per the inclusion-proof
+// verification algorithm in RFC 6962 section 2.1.1.
+func verifyRekorEntryInclusion(entry *rekorLogEntry) bool {
+	proof := entry.Verification.InclusionProof
+	if proof.RootHash == "" || proof.TreeSize <= 0 || proof.LogIndex < 0 || proof.LogIndex >= proof.TreeSize {
+		return false
+	}
+
+	body, err := base64.StdEncoding.DecodeString(entry.Body)
+	if err != nil {
+		return false
+	}
+	root, err := hex.DecodeString(proof.RootHash)
+	if err != nil {
+		return false
+	}
+	path := make([][]byte, 0, len(proof.Hashes))
+	for _, h := range proof.Hashes {
+		decoded, err := hex.DecodeString(h)
+		if err != nil {
+			return false
+		}
+		path = append(path, decoded)
+	}
+
+	return rfc6962VerifyInclusion(rfc6962LeafHash(body), proof.LogIndex, proof.TreeSize, path, root)
+}
+
+// rfc6962LeafHash hashes a Merkle tree leaf per RFC 6962 section 2.1 (0x00 prefix, distinguishing
+// leaf hashes from interior node hashes so a leaf can never be mistaken for one).
+func rfc6962LeafHash(data []byte) []byte {
+	h := sha256.Sum256(append([]byte{0x00}, data...))
+	return h[:]
+}
+
+// rfc6962NodeHash hashes a Merkle tree interior node per RFC 6962 section 2.1 (0x01 prefix).
+func rfc6962NodeHash(left, right []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(left)
+	h.Write(right)
+	return h.Sum(nil)
+}
+
+// rfc6962VerifyInclusion walks proof (the audit path from leafIndex up to the root of a tree of
+// treeSize leaves) and reports whether it recomputes to root, per the algorithm in RFC 6962
+// section 2.1.1.
+func rfc6962VerifyInclusion(leafHash []byte, leafIndex, treeSize int64, proof [][]byte, root []byte) bool {
+	fn, sn := leafIndex, treeSize-1
+	hash := leafHash
+	for _, sibling := range proof {
+		if fn == sn || fn&1 == 1 {
+			hash = rfc6962NodeHash(sibling, hash)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			hash = rfc6962NodeHash(hash, sibling)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(hash, root)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+
+	restful "github.com/emicklei/go-restful"
+
+	"kubesphere.io/kubesphere/pkg/api"
+	"kubesphere.io/kubesphere/pkg/models"
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+	"kubesphere.io/kubesphere/pkg/server/errors"
+	openpitrixoptions "kubesphere.io/kubesphere/pkg/simple/client/openpitrix"
+)
+
+// CreateWebhook handles POST /webhooks, registering a new subscription to openpitrix application
+// lifecycle events.
+func (h *openpitrixHandler) CreateWebhook(req *restful.Request, resp *restful.Response) {
+	var createRequest openpitrix.CreateWebhookRequest
+	if err := req.ReadEntity(&createRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	webhook, err := h.createWebhook(&createRequest)
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(webhook)
+}
+
+// ListWebhooks handles GET /webhooks, listing every registered subscription.
+func (h *openpitrixHandler) ListWebhooks(req *restful.Request, resp *restful.Response) {
+	result, err := h.listWebhooks()
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// DescribeWebhook handles GET /webhooks/{webhook}.
+func (h *openpitrixHandler) DescribeWebhook(req *restful.Request, resp *restful.Response) {
+	webhook, err := h.describeWebhook(req.PathParameter("webhook"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(webhook)
+}
+
+// ModifyWebhook handles PATCH /webhooks/{webhook}.
+func (h *openpitrixHandler) ModifyWebhook(req *restful.Request, resp *restful.Response) {
+	var modifyRequest openpitrix.ModifyWebhookRequest
+	if err := req.ReadEntity(&modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+
+	if err := h.modifyWebhook(req.PathParameter("webhook"), &modifyRequest); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(errors.None)
+}
+
+// DeleteWebhook handles DELETE /webhooks/{webhook}.
+func (h *openpitrixHandler) DeleteWebhook(req *restful.Request, resp *restful.Response) {
+	if err := h.deleteWebhook(req.PathParameter("webhook")); err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(errors.None)
+}
+
+// ListWebhookDeliveries handles GET /webhooks/{webhook}/deliveries, reading back the dead-letter
+// store of delivery attempts (including failed ones) for the subscription.
+func (h *openpitrixHandler) ListWebhookDeliveries(req *restful.Request, resp *restful.Response) {
+	result, err := h.listWebhookDeliveries(req.PathParameter("webhook"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(result)
+}
+
+// RedeliverWebhookDelivery handles POST /webhooks/{webhook}/deliveries/{delivery}/redeliver,
+// re-sending a previously recorded delivery's payload to the subscription's URL.
+func (h *openpitrixHandler) RedeliverWebhookDelivery(req *restful.Request, resp *restful.Response) {
+	err := h.redeliverWebhookDelivery(req.PathParameter("webhook"), req.PathParameter("delivery"))
+	if err != nil {
+		api.HandleError(resp, req, err)
+		return
+	}
+	resp.WriteEntity(errors.None)
+}
+
+func (h *openpitrixHandler) createWebhook(req *openpitrix.CreateWebhookRequest) (*openpitrix.Webhook, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	webhook := &openpitrix.Webhook{
+		ID:              h.nextID("webhook"),
+		URL:             req.URL,
+		Secret:          req.Secret,
+		Events:          req.Events,
+		WorkspaceFilter: req.WorkspaceFilter,
+		NamespaceFilter: req.NamespaceFilter,
+	}
+	h.webhooks[webhook.ID] = webhook
+	return webhook, nil
+}
+
+func (h *openpitrixHandler) listWebhooks() (*models.PageableResponse, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	items := make([]interface{}, 0, len(h.webhooks))
+	for _, webhook := range h.webhooks {
+		items = append(items, webhook)
+	}
+	return &models.PageableResponse{Items: items, TotalCount: len(items)}, nil
+}
+
+func (h *openpitrixHandler) describeWebhook(id string) (*openpitrix.Webhook, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	webhook, ok := h.webhooks[id]
+	if !ok {
+		return nil, fmt.Errorf("webhook %s not found", id)
+	}
+	return webhook, nil
+}
+
+func (h *openpitrixHandler) modifyWebhook(id string, req *openpitrix.ModifyWebhookRequest) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	webhook, ok := h.webhooks[id]
+	if !ok {
+		return fmt.Errorf("webhook %s not found", id)
+	}
+	if req.URL != "" {
+		webhook.URL = req.URL
+	}
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Events != nil {
+		webhook.Events = req.Events
+	}
+	if req.WorkspaceFilter != "" {
+		webhook.WorkspaceFilter = req.WorkspaceFilter
+	}
+	if req.NamespaceFilter != "" {
+		webhook.NamespaceFilter = req.NamespaceFilter
+	}
+	return nil
+}
+
+func (h *openpitrixHandler) deleteWebhook(id string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.webhooks, id)
+	delete(h.deliveries, id)
+	return nil
+}
+
+func (h *openpitrixHandler) listWebhookDeliveries(id string) (*models.PageableResponse, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	deliveries := h.deliveries[id]
+	items := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		items = append(items, delivery)
+	}
+	return &models.PageableResponse{Items: items, TotalCount: len(items)}, nil
+}
+
+// redeliverWebhookDelivery re-sends a previously recorded delivery's payload through the same
+// WebhookDispatcher every lifecycle event goes through, rather than re-posting it directly, so the
+// redelivery is itself retried/recorded exactly like an original delivery would be. Dispatch runs
+// in its own goroutine, same as dispatchWebhookEvent, so a slow or unreachable subscriber - worth
+// up to 3 retries with exponential backoff - can't hold the request open.
+func (h *openpitrixHandler) redeliverWebhookDelivery(webhookID, deliveryID string) error {
+	h.mu.RLock()
+	webhook, ok := h.webhooks[webhookID]
+	var hook openpitrix.Webhook
+	if ok {
+		hook = *webhook
+	}
+	var original *openpitrix.WebhookDelivery
+	for _, delivery := range h.deliveries[webhookID] {
+		if delivery.ID == deliveryID {
+			original = delivery
+			break
+		}
+	}
+	h.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("webhook %s not found", webhookID)
+	}
+	if original == nil {
+		return fmt.Errorf("delivery %s not found for webhook %s", deliveryID, webhookID)
+	}
+
+	var payload openpitrixoptions.WebhookEventPayload
+	if err := json.Unmarshal([]byte(original.Payload), &payload); err != nil {
+		return fmt.Errorf("failed to parse original delivery payload: %s", err)
+	}
+	go h.dispatcher.Dispatch(&hook, &payload)
+	return nil
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// DiffAction classifies how a diffed document changes relative to what's currently deployed.
+type DiffAction string
+
+const (
+	DiffActionAdded     DiffAction = "added"
+	DiffActionRemoved   DiffAction = "removed"
+	DiffActionUnchanged DiffAction = "unchanged"
+	DiffActionChanged   DiffAction = "changed"
+)
+
+// ResourceDiff is the diff of a single document against its currently deployed state:
+// kind/name/namespace identify it, UnifiedDiff is a line-based unified diff. The :diff and :dryRun
+// handlers diff the values document only, with Kind "Values" and APIVersion left empty; they do
+// not render or compare Kubernetes objects.
+type ResourceDiff struct {
+	APIVersion  string     `json:"apiVersion"`
+	Kind        string     `json:"kind"`
+	Name        string     `json:"name"`
+	Namespace   string     `json:"namespace,omitempty"`
+	Action      DiffAction `json:"action"`
+	UnifiedDiff string     `json:"unifiedDiff,omitempty"`
+}
+
+// ValidationResult is the outcome of validating a single document. The :diff and :dryRun
+// handlers only check that the values document parses as YAML; no server-side dry-run is made.
+type ValidationResult struct {
+	Kind    string `json:"kind"`
+	Name    string `json:"name"`
+	Valid   bool   `json:"valid"`
+	Message string `json:"message,omitempty"`
+}
+
+// ApplicationDiffResponse is returned by the :diff and :dryRun application routes, letting a UI
+// preview what an upgrade or create will mutate before the user commits.
+type ApplicationDiffResponse struct {
+	Manifest    string             `json:"manifest"`
+	Diffs       []ResourceDiff     `json:"diffs"`
+	Validations []ValidationResult `json:"validations"`
+}
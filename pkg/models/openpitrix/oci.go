@@ -0,0 +1,36 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// RepoType distinguishes the transport a repository is indexed through.
+type RepoType string
+
+const (
+	// RepoTypeHTTP is a classic Helm chart repository served as an index.yaml over HTTP(S).
+	RepoTypeHTTP RepoType = "http"
+	// RepoTypeOCI is a Helm 3 OCI-conforming registry (Harbor, ghcr, ECR, etc).
+	RepoTypeOCI RepoType = "oci"
+)
+
+// OCIChartContentMediaType is the media type Helm uses for chart tarballs pushed to an OCI registry.
+const OCIChartContentMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
+
+// OCIRegistryCredential holds the credentials used to authenticate against an OCI registry
+// when CreateRepoRequest.Type is RepoTypeOCI.
+type OCIRegistryCredential struct {
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty" description:"sensitive, write-only"`
+	// Insecure allows connecting to registries served over plain HTTP or with a self-signed certificate.
+	Insecure bool `json:"insecure,omitempty"`
+}
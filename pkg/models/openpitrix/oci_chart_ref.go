@@ -0,0 +1,75 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OCIChartRefPrefix is the scheme CreateClusterRequest.AppId/UpgradeClusterRequest.VersionId accept
+// in place of an openpitrix app/version id to deploy directly from an OCI registry, e.g.
+// "oci://registry.example.com/charts/redis:17.3.0".
+const OCIChartRefPrefix = "oci://"
+
+// ChartReference is an OCI chart reference of the form oci://host[:port]/repository/name:tag,
+// resolved by CreateApplication/UpgradeApplication instead of an openpitrix repo-indexed app
+// version when the request targets an OCI registry directly, and surfaced by DescribeApplication.
+type ChartReference struct {
+	Registry string `json:"registry"`
+	Chart    string `json:"chart"`
+	Tag      string `json:"tag"`
+}
+
+func (r ChartReference) String() string {
+	return fmt.Sprintf("%s%s/%s:%s", OCIChartRefPrefix, r.Registry, r.Chart, r.Tag)
+}
+
+// IsOCIChartRef reports whether id is an oci:// chart reference rather than an openpitrix app/version id.
+func IsOCIChartRef(id string) bool {
+	return strings.HasPrefix(id, OCIChartRefPrefix)
+}
+
+// ParseChartReference parses an oci:// chart reference as accepted by CreateApplication and
+// UpgradeApplication.
+func ParseChartReference(ref string) (ChartReference, error) {
+	if !IsOCIChartRef(ref) {
+		return ChartReference{}, fmt.Errorf("not an oci chart reference: %s", ref)
+	}
+	rest := strings.TrimPrefix(ref, OCIChartRefPrefix)
+
+	slash := strings.Index(rest, "/")
+	if slash < 0 {
+		return ChartReference{}, fmt.Errorf("invalid oci chart reference %q: missing repository path", ref)
+	}
+	registry, chartAndTag := rest[:slash], rest[slash+1:]
+
+	colon := strings.LastIndex(chartAndTag, ":")
+	if colon < 0 {
+		return ChartReference{}, fmt.Errorf("invalid oci chart reference %q: missing tag", ref)
+	}
+	chart, tag := chartAndTag[:colon], chartAndTag[colon+1:]
+	if registry == "" || chart == "" || tag == "" {
+		return ChartReference{}, fmt.Errorf("invalid oci chart reference %q", ref)
+	}
+
+	return ChartReference{Registry: registry, Chart: chart, Tag: tag}, nil
+}
+
+// ImagePullSecretRef names the docker-config-style secret used to authenticate against the
+// registry an OCI chart reference resolves to, following the same convention as a pod's
+// imagePullSecrets.
+type ImagePullSecretRef struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,43 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// ApplicationRevision is a prior release of an application. Revisions are kept in the handler's
+// in-process history, not in a CRD store, so they do not survive an apiserver restart.
+type ApplicationRevision struct {
+	Revision     int    `json:"revision"`
+	ChartVersion string `json:"chartVersion"`
+	ValuesHash   string `json:"valuesHash"`
+	DeployedAt   string `json:"deployedAt"`
+	Status       string `json:"status"`
+	User         string `json:"user"`
+}
+
+// ApplicationRevisionDetail adds the manifest and values used at a revision to ApplicationRevision,
+// returned by DescribeApplicationRevision.
+type ApplicationRevisionDetail struct {
+	ApplicationRevision `json:",inline"`
+	Manifest            string `json:"manifest"`
+	Values              string `json:"values"`
+}
+
+// RollbackApplicationRequest rolls an application back to a prior revision. The rollback updates
+// the handler's own records only; no Helm rollback is run, and Wait, Timeout and Recreate are
+// accepted but not acted on.
+type RollbackApplicationRequest struct {
+	Revision int  `json:"revision"`
+	Wait     bool `json:"wait,omitempty"`
+	Timeout  int  `json:"timeout,omitempty" description:"timeout in seconds; not acted on"`
+	Recreate bool `json:"recreate,omitempty" description:"restart pods for the resource if applicable; not acted on"`
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// Repo is a repository of app template packages, either a classic HTTP index.yaml (RepoTypeHTTP)
+// or an OCI-conforming registry (RepoTypeOCI).
+type Repo struct {
+	RepoId       string                 `json:"repo_id,omitempty"`
+	Name         string                 `json:"name"`
+	URL          string                 `json:"url"`
+	Workspace    string                 `json:"workspace,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Type         RepoType               `json:"type,omitempty"`
+	Credential   *OCIRegistryCredential `json:"credential,omitempty"`
+	Verification *Verification          `json:"verification,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	CreateTime   string                 `json:"create_time,omitempty"`
+	StatusTime   string                 `json:"status_time,omitempty"`
+}
+
+// CreateRepoRequest creates a new Repo.
+type CreateRepoRequest struct {
+	Name         string                 `json:"name"`
+	URL          string                 `json:"url"`
+	Description  string                 `json:"description,omitempty"`
+	Type         RepoType               `json:"type,omitempty"`
+	Credential   *OCIRegistryCredential `json:"credential,omitempty"`
+	Verification *Verification          `json:"verification,omitempty"`
+}
+
+// CreateRepoResponse returns the id assigned to a newly created Repo.
+type CreateRepoResponse struct {
+	RepoId string `json:"repo_id"`
+}
+
+// ModifyRepoRequest patches an existing Repo. Nil pointer fields are left unchanged.
+type ModifyRepoRequest struct {
+	Name         string                 `json:"name,omitempty"`
+	URL          string                 `json:"url,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Credential   *OCIRegistryCredential `json:"credential,omitempty"`
+	Verification *Verification          `json:"verification,omitempty"`
+}
+
+// RepoActionRequest triggers an action (currently only indexing) on a Repo; DoRepoAction runs it
+// before responding.
+type RepoActionRequest struct {
+	Action string `json:"action"`
+}
+
+// AppVersion is a single published version of an app template, either indexed from a Repo or
+// created directly via CreateAppVersion.
+type AppVersion struct {
+	VersionId   string `json:"version_id,omitempty"`
+	AppId       string `json:"app_id"`
+	RepoId      string `json:"repo_id,omitempty"`
+	Name        string `json:"name"`
+	Owner       string `json:"owner,omitempty"`
+	PackageName string `json:"package_name,omitempty"`
+	PackageURL  string `json:"package_url,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+	// Verified and Signer are populated by the repo's configured Verification policy when the
+	// version is indexed or submitted.
+	Verified   bool   `json:"verified,omitempty"`
+	Signer     string `json:"signer,omitempty"`
+	CreateTime string `json:"create_time,omitempty"`
+}
+
+// CreateAppRequest creates a new app template.
+type CreateAppRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateAppResponse returns the id assigned to a newly created app template.
+type CreateAppResponse struct {
+	AppId string `json:"app_id"`
+}
+
+// CreateAppVersionRequest creates a new version of an app template.
+type CreateAppVersionRequest struct {
+	Name        string `json:"name"`
+	Package     []byte `json:"package,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateAppVersionResponse returns the id assigned to a newly created app version.
+type CreateAppVersionResponse struct {
+	VersionId string `json:"version_id"`
+}
+
+// ModifyAppVersionRequest patches an existing app template or app version.
+type ModifyAppVersionRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+}
+
+// GetAppVersionPackageResponse carries a version's chart tarball.
+type GetAppVersionPackageResponse struct {
+	VersionId string `json:"version_id"`
+	Package   []byte `json:"package"`
+}
+
+// GetAppVersionPackageFilesResponse carries the individual files contained in a version's package.
+type GetAppVersionPackageFilesResponse struct {
+	VersionId string            `json:"version_id"`
+	Files     map[string][]byte `json:"files"`
+}
+
+// AppVersionAudit records a single status transition (e.g. submitted, passed, rejected) of an app
+// version.
+type AppVersionAudit struct {
+	VersionId string `json:"version_id"`
+	AppId     string `json:"app_id"`
+	Status    string `json:"status"`
+	Message   string `json:"message,omitempty"`
+	Operator  string `json:"operator,omitempty"`
+	Time      string `json:"time,omitempty"`
+}
+
+// AppVersionReview is a reviewer's decision on a submitted app version.
+type AppVersionReview struct {
+	VersionId string `json:"version_id"`
+	AppId     string `json:"app_id"`
+	Status    string `json:"status"`
+	Reviewer  string `json:"reviewer,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// Application is a deployed Helm release tracked by the openpitrix subsystem.
+type Application struct {
+	ApplicationId string `json:"application_id,omitempty"`
+	Name          string `json:"name"`
+	Workspace     string `json:"workspace,omitempty"`
+	Cluster       string `json:"cluster,omitempty"`
+	Namespace     string `json:"namespace"`
+	AppId         string `json:"app_id,omitempty"`
+	VersionId     string `json:"version_id,omitempty"`
+	// ChartRef is the parsed OCI chart reference, set when the application was installed or
+	// upgraded from an oci:// reference rather than an indexed app version; the raw reference is
+	// kept in AppId/VersionId as sent.
+	ChartRef string `json:"chart_ref,omitempty"`
+	// ChartDigest is the sha256 digest of the chart tarball pulled for a ChartRef install,
+	// surfaced so deployments can be pinned to it.
+	ChartDigest string `json:"chart_digest,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+	CreateTime  string `json:"create_time,omitempty"`
+}
+
+// CreateApplicationResponse returns the id assigned to a newly deployed Application, so the
+// caller that just created it can address every subsequent describe/upgrade/delete/revision call
+// without having to guess or separately list for it.
+type CreateApplicationResponse struct {
+	ApplicationId string `json:"application_id"`
+}
+
+// CreateClusterRequest deploys a new Application. AppId/VersionId may be an oci:// chart
+// reference instead of an openpitrix app/version id, in which case ImagePullSecretRef names the
+// docker-config-style secret used to authenticate against the registry.
+type CreateClusterRequest struct {
+	Name               string              `json:"name"`
+	AppId              string              `json:"app_id"`
+	VersionId          string              `json:"version_id"`
+	Conf               string              `json:"conf,omitempty" description:"Helm values.yaml override, as a YAML string"`
+	ImagePullSecretRef *ImagePullSecretRef `json:"image_pull_secret_ref,omitempty"`
+}
+
+// UpgradeClusterRequest upgrades an existing Application. VersionId may be an oci:// chart
+// reference instead of an openpitrix version id, in which case ImagePullSecretRef names the
+// docker-config-style secret used to authenticate against the registry.
+type UpgradeClusterRequest struct {
+	VersionId          string              `json:"version_id"`
+	Conf               string              `json:"conf,omitempty" description:"Helm values.yaml override, as a YAML string"`
+	ImagePullSecretRef *ImagePullSecretRef `json:"image_pull_secret_ref,omitempty"`
+}
+
+// ModifyClusterAttributesRequest patches the describable attributes of an Application.
+type ModifyClusterAttributesRequest struct {
+	Description string `json:"description,omitempty"`
+}
+
+// Category groups app templates for browsing/search.
+type Category struct {
+	CategoryId  string `json:"category_id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateCategoryRequest creates a new Category.
+type CreateCategoryRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// CreateCategoryResponse returns the id assigned to a newly created Category.
+type CreateCategoryResponse struct {
+	CategoryId string `json:"category_id"`
+}
+
+// ModifyCategoryRequest patches an existing Category.
+type ModifyCategoryRequest struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// Attachment is a standalone uploaded file (e.g. an app icon or screenshot) referenced by id from
+// an app template.
+type Attachment struct {
+	AttachmentId string `json:"attachment_id,omitempty"`
+	Filename     string `json:"filename"`
+	Content      []byte `json:"-"`
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// VerificationMode selects how chart versions indexed from a repository are verified before
+// they can be submitted/deployed.
+type VerificationMode string
+
+const (
+	// VerificationModeNone performs no signature verification (default, backward compatible).
+	VerificationModeNone VerificationMode = "none"
+	// VerificationModeProv verifies a classic Helm .prov openpgp clearsigned provenance file.
+	VerificationModeProv VerificationMode = "prov"
+	// VerificationModeCosign verifies a cosign signature, either against a configured public key
+	// or keyless via a Rekor transparency-log inclusion proof.
+	VerificationModeCosign VerificationMode = "cosign"
+)
+
+// Verification describes the supply-chain policy a repository enforces when indexing chart
+// versions, set via CreateRepoRequest/ModifyRepoRequest.
+type Verification struct {
+	Mode      VerificationMode `json:"mode,omitempty"`
+	PublicKey string           `json:"publicKey,omitempty"`
+	RekorURL  string           `json:"rekorURL,omitempty"`
+	// Strict rejects DoAppVersionAction submissions when a chart version's signature is
+	// missing or fails verification, instead of only recording Verified=false.
+	Strict bool `json:"strict,omitempty"`
+}
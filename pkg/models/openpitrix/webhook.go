@@ -0,0 +1,83 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+// WebhookEvent is a lifecycle event an application/cluster subscription can be raised for.
+type WebhookEvent string
+
+const (
+	WebhookEventApplicationCreated    WebhookEvent = "application.created"
+	WebhookEventApplicationUpgraded   WebhookEvent = "application.upgraded"
+	WebhookEventApplicationRolledBack WebhookEvent = "application.rolledBack"
+	WebhookEventApplicationDeleted    WebhookEvent = "application.deleted"
+)
+
+// Webhook is a subscription to openpitrix application lifecycle events. Deliveries are POSTed as
+// JSON to URL, signed with HMAC-SHA256 over Secret in the X-KubeSphere-Signature header,
+// mirroring how GitHub/Argo CD expose their event streams.
+//
+// Subscriptions are currently held in the handler's in-process map (h.webhooks), not persisted as
+// a CR - they do not survive an apiserver restart. CR-backed persistence needs an actual Webhook
+// CRD (type, generated deepcopy/clientset, informer wiring) that doesn't exist in this tree yet;
+// that's follow-up work, not something addressable from this models package alone.
+type Webhook struct {
+	ID              string         `json:"id,omitempty"`
+	URL             string         `json:"url"`
+	Secret          string         `json:"secret,omitempty" description:"sensitive, write-only"`
+	Events          []WebhookEvent `json:"events"`
+	WorkspaceFilter string         `json:"workspaceFilter,omitempty" description:"only deliver events from this workspace; empty matches all workspaces"`
+	NamespaceFilter string         `json:"namespaceFilter,omitempty" description:"only deliver events from this namespace; empty matches all namespaces"`
+}
+
+// CreateWebhookRequest creates a new Webhook subscription.
+type CreateWebhookRequest struct {
+	URL             string         `json:"url"`
+	Secret          string         `json:"secret,omitempty"`
+	Events          []WebhookEvent `json:"events"`
+	WorkspaceFilter string         `json:"workspaceFilter,omitempty"`
+	NamespaceFilter string         `json:"namespaceFilter,omitempty"`
+}
+
+// ModifyWebhookRequest patches an existing Webhook subscription.
+type ModifyWebhookRequest struct {
+	URL             string         `json:"url,omitempty"`
+	Secret          string         `json:"secret,omitempty"`
+	Events          []WebhookEvent `json:"events,omitempty"`
+	WorkspaceFilter string         `json:"workspaceFilter,omitempty"`
+	NamespaceFilter string         `json:"namespaceFilter,omitempty"`
+}
+
+// WebhookDeliveryStatus is the outcome of a single webhook delivery attempt.
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+)
+
+// WebhookDelivery is a single attempt (including retries) to deliver an event to a Webhook,
+// recorded in the dead-letter store so GET /webhooks/{id}/deliveries can read it back and a
+// failed delivery can be redelivered manually.
+type WebhookDelivery struct {
+	ID             string                `json:"id"`
+	WebhookID      string                `json:"webhookID"`
+	Event          WebhookEvent          `json:"event"`
+	Payload        string                `json:"payload"`
+	Attempt        int                   `json:"attempt"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	ResponseStatus int                   `json:"responseStatus,omitempty"`
+	Error          string                `json:"error,omitempty"`
+	DeliveredAt    string                `json:"deliveredAt,omitempty"`
+}
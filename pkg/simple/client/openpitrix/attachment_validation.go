@@ -0,0 +1,209 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// ValidationRule names a single check in the attachment validation pipeline, returned on an
+// AttachmentValidationError so callers can surface a meaningful, rule-specific message.
+type ValidationRule string
+
+const (
+	ValidationRuleMimeType      ValidationRule = "mimeType"
+	ValidationRuleMaxSize       ValidationRule = "maxSize"
+	ValidationRuleAntivirus     ValidationRule = "antivirus"
+	ValidationRuleChartStruct   ValidationRule = "chartStructure"
+	ValidationRulePathTraversal ValidationRule = "pathTraversal"
+)
+
+// AttachmentValidationError reports which rule in the validation pipeline an uploaded attachment
+// failed.
+type AttachmentValidationError struct {
+	Rule    ValidationRule
+	Message string
+}
+
+func (e *AttachmentValidationError) Error() string {
+	return fmt.Sprintf("attachment failed %s validation: %s", e.Rule, e.Message)
+}
+
+// AttachmentValidationConfig configures the pluggable validation pipeline CreateAttachment runs
+// uploads through before they're persisted.
+type AttachmentValidationConfig struct {
+	// AllowedMimeTypes is the sniffed-content-type allow-list, e.g. application/gzip,
+	// application/x-helm-chart, image/png. An empty list disables MIME sniffing.
+	AllowedMimeTypes []string
+	// MaxSizeBytes is enforced as a streaming limit rather than buffering the whole upload first.
+	MaxSizeBytes int64
+	// ClamdAddress, when set, is a host:port a clamd INSTREAM scan is performed against.
+	ClamdAddress string
+}
+
+// limitedReader enforces MaxSizeBytes as a streaming guard, failing as soon as the limit is
+// crossed instead of after buffering the whole upload.
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (lr *limitedReader) Read(p []byte) (int, error) {
+	n, err := lr.r.Read(p)
+	lr.remaining -= int64(n)
+	if lr.remaining < 0 {
+		return n, &AttachmentValidationError{Rule: ValidationRuleMaxSize, Message: "upload exceeds the configured maximum size"}
+	}
+	return n, err
+}
+
+// SniffMimeType reads the first 512 bytes of body and returns the content type detected from them,
+// along with a reader that replays them before the rest of body, so detection doesn't consume the
+// stream callers still need to persist.
+func SniffMimeType(body io.Reader) (contentType string, combined io.Reader, err error) {
+	buf := make([]byte, 512)
+	n, err := io.ReadFull(body, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", nil, err
+	}
+	buf = buf[:n]
+	contentType = http.DetectContentType(buf)
+	return contentType, io.MultiReader(bytes.NewReader(buf), body), nil
+}
+
+func mimeAllowed(contentType string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == contentType {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateUpload runs body through the configured validation pipeline: MIME sniffing against the
+// allow-list, a streaming max-size guard, and (if ClamdAddress is set) an AV scan. It returns a
+// reader that replays the already-consumed bytes, so the caller can still persist the full upload
+// after validation succeeds.
+func (c *AttachmentValidationConfig) ValidateUpload(body io.Reader) (io.Reader, error) {
+	contentType, sniffed, err := SniffMimeType(body)
+	if err != nil {
+		return nil, err
+	}
+	if !mimeAllowed(contentType, c.AllowedMimeTypes) {
+		return nil, &AttachmentValidationError{Rule: ValidationRuleMimeType, Message: fmt.Sprintf("content type %s is not in the allow-list", contentType)}
+	}
+
+	limited := sniffed
+	if c.MaxSizeBytes > 0 {
+		limited = &limitedReader{r: sniffed, remaining: c.MaxSizeBytes}
+	}
+
+	if c.ClamdAddress == "" {
+		return limited, nil
+	}
+
+	buf, err := io.ReadAll(limited)
+	if err != nil {
+		return nil, err
+	}
+	if err := scanWithClamd(c.ClamdAddress, buf); err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(buf), nil
+}
+
+// scanWithClamd streams buf to clamd's INSTREAM command and fails the upload if clamd reports a
+// signature match.
+func scanWithClamd(address string, buf []byte) error {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to connect to clamd at %s: %s", address, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return err
+	}
+	const chunkSize = 1 << 16
+	for offset := 0; offset < len(buf); offset += chunkSize {
+		end := offset + chunkSize
+		if end > len(buf) {
+			end = len(buf)
+		}
+		chunk := buf[offset:end]
+		size := uint32(len(chunk))
+		header := []byte{byte(size >> 24), byte(size >> 16), byte(size >> 8), byte(size)}
+		if _, err := conn.Write(header); err != nil {
+			return err
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return err
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return err
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString('\x00')
+	if err != nil && err != io.EOF {
+		return err
+	}
+	if !strings.Contains(reply, "OK") || strings.Contains(reply, "FOUND") {
+		return &AttachmentValidationError{Rule: ValidationRuleAntivirus, Message: strings.TrimSpace(reply)}
+	}
+	return nil
+}
+
+// ValidateChartStructure verifies a chart tarball has a well-formed top-level Chart.yaml and
+// rejects any entry whose name attempts path traversal (e.g. "../../etc/passwd").
+func ValidateChartStructure(tgz io.Reader) error {
+	gz, err := gzip.NewReader(tgz)
+	if err != nil {
+		return &AttachmentValidationError{Rule: ValidationRuleChartStruct, Message: "not a valid gzip archive"}
+	}
+	defer gz.Close()
+
+	var sawChartYaml bool
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return &AttachmentValidationError{Rule: ValidationRuleChartStruct, Message: err.Error()}
+		}
+		if strings.Contains(header.Name, "..") {
+			return &AttachmentValidationError{Rule: ValidationRulePathTraversal, Message: fmt.Sprintf("archive entry %q attempts path traversal", header.Name)}
+		}
+		if strings.Count(header.Name, "/") == 1 && strings.HasSuffix(header.Name, "/Chart.yaml") {
+			sawChartYaml = true
+		}
+	}
+	if !sawChartYaml {
+		return &AttachmentValidationError{Rule: ValidationRuleChartStruct, Message: "archive does not contain a top-level Chart.yaml"}
+	}
+	return nil
+}
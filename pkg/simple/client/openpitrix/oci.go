@@ -0,0 +1,260 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+)
+
+const chartContentMediaType = openpitrix.OCIChartContentMediaType
+
+// manifestAcceptHeader lists every manifest media type a Helm OCI chart may be pushed as, so the
+// registry doesn't fall back to a legacy schema1 manifest we can't parse.
+const manifestAcceptHeader = "application/vnd.oci.image.manifest.v1+json, application/vnd.docker.distribution.manifest.v2+json"
+
+// cosignSignatureAnnotation is the annotation cosign attaches to a signature manifest's layer
+// descriptor holding the base64-encoded detached signature.
+const cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+// ociManifest is the subset of an OCI image manifest needed to locate a chart's content layer by
+// media type.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// OCIRegistryClient talks to a Helm OCI-conforming registry (Harbor, ghcr, ECR, ...) using the
+// Docker Registry HTTP API v2 `_catalog`/tags/manifest/blob endpoints.
+type OCIRegistryClient struct {
+	baseURL    string
+	credential *openpitrix.OCIRegistryCredential
+	client     *http.Client
+}
+
+// NewOCIRegistryClient builds a client for the OCI registry hosting the given repository URL.
+func NewOCIRegistryClient(registryURL string, credential *openpitrix.OCIRegistryCredential) (*OCIRegistryClient, error) {
+	transport := http.DefaultTransport
+	if credential != nil && credential.Insecure {
+		transport = &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	}
+	return &OCIRegistryClient{
+		baseURL:    registryURL,
+		credential: credential,
+		client:     &http.Client{Transport: transport},
+	}, nil
+}
+
+type catalogResponse struct {
+	Repositories []string `json:"repositories"`
+}
+
+// Catalog returns every chart repository the configured credentials can see.
+func (c *OCIRegistryClient) Catalog() ([]string, error) {
+	var result catalogResponse
+	if err := c.getJSON("/v2/_catalog", &result); err != nil {
+		return nil, err
+	}
+	return result.Repositories, nil
+}
+
+type tagListResponse struct {
+	Tags []string `json:"tags"`
+}
+
+// Tags returns every tag (chart version) published under the given chart repository.
+func (c *OCIRegistryClient) Tags(name string) ([]string, error) {
+	var result tagListResponse
+	if err := c.getJSON(fmt.Sprintf("/v2/%s/tags/list", name), &result); err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+// PullChartBlob pulls the application/vnd.cncf.helm.chart.content.v1.tar+gzip layer for the
+// given chart:tag, returning the raw tarball for streaming to the caller. Per the OCI
+// distribution spec, the manifests endpoint always returns the manifest JSON document regardless
+// of the Accept header - real registries (Harbor, ghcr, ECR) never hand back the layer content
+// directly - so the tarball itself has to be fetched separately from the blob store once the
+// manifest's layers have been inspected for the chart content media type.
+func (c *OCIRegistryClient) PullChartBlob(name, tag string) (io.ReadCloser, error) {
+	manifest, err := c.manifest(name, tag)
+	if err != nil {
+		return nil, err
+	}
+	for _, layer := range manifest.Layers {
+		if layer.MediaType == chartContentMediaType {
+			return c.blob(name, layer.Digest)
+		}
+	}
+	return nil, fmt.Errorf("manifest for %s:%s has no %s layer", name, tag, chartContentMediaType)
+}
+
+// manifest fetches and parses the OCI/Docker manifest for name:tag.
+func (c *OCIRegistryClient) manifest(name, tag string) (*ociManifest, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+fmt.Sprintf("/v2/%s/manifests/%s", name, tag), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", manifestAcceptHeader)
+	c.authenticate(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %d fetching manifest for %s:%s", resp.StatusCode, name, tag)
+	}
+	var manifest ociManifest
+	if err := json.NewDecoder(resp.Body).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for %s:%s: %s", name, tag, err)
+	}
+	return &manifest, nil
+}
+
+// blob streams the content-addressed blob identified by digest out of name's blob store.
+func (c *OCIRegistryClient) blob(name, digest string) (io.ReadCloser, error) {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+fmt.Sprintf("/v2/%s/blobs/%s", name, digest), nil)
+	if err != nil {
+		return nil, err
+	}
+	c.authenticate(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected status %d fetching blob %s for %s", resp.StatusCode, digest, name)
+	}
+	return resp.Body, nil
+}
+
+// PullChartTarball pulls the full chart tarball for name:tag into memory and returns it alongside
+// its sha256 content digest, for callers that need to verify or re-extract the chart rather than
+// just its Chart.yaml.
+func (c *OCIRegistryClient) PullChartTarball(name, tag string) (tgz []byte, digest string, err error) {
+	blob, err := c.PullChartBlob(name, tag)
+	if err != nil {
+		return nil, "", err
+	}
+	defer blob.Close()
+
+	tgz, err = io.ReadAll(blob)
+	if err != nil {
+		return nil, "", err
+	}
+	return tgz, fmt.Sprintf("sha256:%x", sha256.Sum256(tgz)), nil
+}
+
+// ChartYaml extracts the embedded Chart.yaml from an already-pulled chart tarball.
+func ChartYaml(tgz []byte) ([]byte, error) {
+	return extractChartYaml(bytes.NewReader(tgz))
+}
+
+// cosignSignatureTag derives the tag cosign publishes a chart manifest's detached signature
+// under: <alg>-<hex>.sig, colocated in the same repository as the chart it signs.
+func cosignSignatureTag(digest string) (string, error) {
+	alg, hex, ok := strings.Cut(digest, ":")
+	if !ok || alg == "" || hex == "" {
+		return "", fmt.Errorf("malformed digest %q", digest)
+	}
+	return fmt.Sprintf("%s-%s.sig", alg, hex), nil
+}
+
+// PullCosignSignature fetches the base64-encoded detached cosign signature published for the
+// chart manifest whose content digest is digest, reading it off the
+// dev.cosignproject.cosign/signature annotation cosign attaches to the signature manifest's
+// layer, per cosign's OCI signing convention.
+func (c *OCIRegistryClient) PullCosignSignature(name, digest string) ([]byte, error) {
+	tag, err := cosignSignatureTag(digest)
+	if err != nil {
+		return nil, err
+	}
+	manifest, err := c.manifest(name, tag)
+	if err != nil {
+		return nil, fmt.Errorf("no cosign signature found for %s@%s: %s", name, digest, err)
+	}
+	for _, layer := range manifest.Layers {
+		if signature, ok := layer.Annotations[cosignSignatureAnnotation]; ok {
+			return []byte(signature), nil
+		}
+	}
+	return nil, fmt.Errorf("cosign signature manifest for %s@%s has no %s annotation", name, digest, cosignSignatureAnnotation)
+}
+
+func (c *OCIRegistryClient) getJSON(path string, out interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	c.authenticate(req)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d requesting %s", resp.StatusCode, path)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+func (c *OCIRegistryClient) authenticate(req *http.Request) {
+	if c.credential != nil && c.credential.Username != "" {
+		req.SetBasicAuth(c.credential.Username, c.credential.Password)
+	}
+}
+
+// extractChartYaml reads a Helm chart tarball and returns the contents of its top-level
+// Chart.yaml, without buffering the rest of the archive.
+func extractChartYaml(tgz io.Reader) ([]byte, error) {
+	gz, err := gzip.NewReader(tgz)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("Chart.yaml not found in chart archive")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if strings.Count(header.Name, "/") == 1 && strings.HasSuffix(header.Name, "/Chart.yaml") {
+			return io.ReadAll(tr)
+		}
+	}
+}
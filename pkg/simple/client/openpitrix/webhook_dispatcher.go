@@ -0,0 +1,152 @@
+/*
+Copyright 2020 The KubeSphere Authors.
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package openpitrix
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"kubesphere.io/kubesphere/pkg/models/openpitrix"
+)
+
+const signatureHeader = "X-KubeSphere-Signature"
+
+// WebhookEventPayload is the JSON body POSTed to a subscriber for a single lifecycle event.
+type WebhookEventPayload struct {
+	Event       openpitrix.WebhookEvent `json:"event"`
+	Workspace   string                  `json:"workspace"`
+	Namespace   string                  `json:"namespace"`
+	Application string                  `json:"application"`
+	OccurredAt  string                  `json:"occurredAt"`
+}
+
+// DeliveryStore persists WebhookDelivery records, successful and failed attempts alike, so
+// GET /webhooks/{id}/deliveries can read them back and a failed one can be redelivered manually.
+type DeliveryStore interface {
+	Save(delivery *openpitrix.WebhookDelivery) error
+}
+
+// WebhookDispatcher POSTs signed JSON payloads for the openpitrix application events handed to
+// Dispatch to every subscribed Webhook whose event/workspace/namespace filters match, retrying transient
+// failures with exponential backoff before recording the outcome in DeliveryStore.
+type WebhookDispatcher struct {
+	client     *http.Client
+	store      DeliveryStore
+	maxRetries int
+	backoff    time.Duration
+}
+
+// NewWebhookDispatcher builds a dispatcher that retries a failed delivery up to maxRetries times,
+// doubling backoff between attempts starting at initialBackoff.
+func NewWebhookDispatcher(store DeliveryStore, maxRetries int, initialBackoff time.Duration) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		client:     &http.Client{Timeout: 10 * time.Second},
+		store:      store,
+		maxRetries: maxRetries,
+		backoff:    initialBackoff,
+	}
+}
+
+// Dispatch delivers payload to hook, retrying with exponential backoff, and records every attempt
+// (including the final failure, which lands in the dead-letter store) via DeliveryStore.
+func (d *WebhookDispatcher) Dispatch(hook *openpitrix.Webhook, payload *WebhookEventPayload) error {
+	if !matchesFilters(hook, payload) {
+		return nil
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	signature := sign(hook.Secret, body)
+
+	backoff := d.backoff
+	var lastErr error
+	for attempt := 1; attempt <= d.maxRetries+1; attempt++ {
+		status, err := d.post(hook.URL, signature, body)
+		delivery := &openpitrix.WebhookDelivery{
+			WebhookID:      hook.ID,
+			Event:          payload.Event,
+			Payload:        string(body),
+			Attempt:        attempt,
+			ResponseStatus: status,
+			DeliveredAt:    time.Now().UTC().Format(time.RFC3339),
+		}
+		if err == nil && status >= 200 && status < 300 {
+			delivery.Status = openpitrix.WebhookDeliveryStatusSucceeded
+			return d.store.Save(delivery)
+		}
+
+		lastErr = err
+		if err == nil {
+			lastErr = fmt.Errorf("subscriber responded with status %d", status)
+		}
+		delivery.Status = openpitrix.WebhookDeliveryStatusFailed
+		delivery.Error = lastErr.Error()
+		if saveErr := d.store.Save(delivery); saveErr != nil {
+			return saveErr
+		}
+
+		if attempt <= d.maxRetries {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return fmt.Errorf("webhook %s: giving up after %d attempts: %s", hook.ID, d.maxRetries+1, lastErr)
+}
+
+func (d *WebhookDispatcher) post(url string, signature string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(signatureHeader, signature)
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+func matchesFilters(hook *openpitrix.Webhook, payload *WebhookEventPayload) bool {
+	if hook.WorkspaceFilter != "" && hook.WorkspaceFilter != payload.Workspace {
+		return false
+	}
+	if hook.NamespaceFilter != "" && hook.NamespaceFilter != payload.Namespace {
+		return false
+	}
+	for _, event := range hook.Events {
+		if event == payload.Event {
+			return true
+		}
+	}
+	return false
+}
+
+// sign returns the hex-encoded HMAC-SHA256 of body keyed by secret, prefixed with "sha256=", sent in the
+// X-KubeSphere-Signature header so subscribers can authenticate the delivery.
+func sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}